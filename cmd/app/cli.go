@@ -24,25 +24,48 @@ type (
 		Format     string        `name:"format" enum:"text,toml" default:"text" help:"Output format for the inspection report."`
 	}
 
+	// PostgreSQLConnectionFlags are the connection flags shared by the commands
+	// that connect to PostgreSQL directly. Unset flags fall back to the libpq
+	// environment variables.
+	PostgreSQLConnectionFlags struct {
+		Host           string `name:"host" env:"PGHOST" required:"" help:"PostgreSQL host."`
+		Port           int    `name:"port" env:"PGPORT" default:"5432" help:"PostgreSQL port."`
+		Database       string `name:"database" env:"PGDATABASE" required:"" help:"PostgreSQL database name."`
+		User           string `name:"user" env:"PGUSER" required:"" help:"PostgreSQL user."`
+		Password       string `name:"password" help:"PostgreSQL password. Defaults to PGPASSWORD when no other password source is given."`
+		PasswordEnv    string `name:"password-env" help:"Environment variable that contains the PostgreSQL password."`
+		PasswordStdin  bool   `name:"password-stdin" help:"Read the PostgreSQL password from stdin."`
+		PasswordPrompt bool   `name:"password-prompt" help:"Prompt securely for the PostgreSQL password."`
+		SSLMode        bool   `name:"sslmode" help:"Require SSL for the PostgreSQL connection."`
+	}
+
+	// StarterConfigFlags are the settings written into a generated starter config.
+	StarterConfigFlags struct {
+		ImportPackagePaths    []string `name:"import-package" help:"Import package path to inspect for exported Go types that should be preferred in TypeMap recommendations. Repeat as needed."`
+		OutPath               string   `name:"out-path" default:"./generated" help:"OutPath to use in the starter config."`
+		OutPackagePath        string   `name:"out-package-path" default:"" help:"OutPackagePath to use in the starter config."`
+		GeneratedTypesPackage string   `name:"generated-types-package" default:"dbtypes" help:"PackageName to use for generated PostgreSQL wrapper types in the starter config."`
+		GeneratedTypesPath    string   `name:"generated-types-path" default:"models/dbtypes" help:"RelativePath to use for generated PostgreSQL wrapper types in the starter config."`
+	}
+
 	// InspectPostgreSQLCmd analyzes a PostgreSQL schema directly from CLI connection flags.
 	InspectPostgreSQLCmd struct {
-		Logging               LoggingConfig `embed:""`
-		Host                  string        `name:"host" required:"" help:"PostgreSQL host."`
-		Port                  int           `name:"port" default:"5432" help:"PostgreSQL port."`
-		Database              string        `name:"database" required:"" help:"PostgreSQL database name."`
-		User                  string        `name:"user" required:"" help:"PostgreSQL user."`
-		Password              string        `name:"password" help:"PostgreSQL password."`
-		PasswordEnv           string        `name:"password-env" help:"Environment variable that contains the PostgreSQL password."`
-		PasswordStdin         bool          `name:"password-stdin" help:"Read the PostgreSQL password from stdin."`
-		PasswordPrompt        bool          `name:"password-prompt" help:"Prompt securely for the PostgreSQL password."`
-		SSLMode               bool          `name:"sslmode" help:"Require SSL for the PostgreSQL connection."`
-		Objects               []string      `name:"object" help:"Database object to inspect. Repeat to limit the inspection scope."`
-		ImportPackagePaths    []string      `name:"import-package" help:"Import package path to inspect for exported Go types that should be preferred in TypeMap recommendations. Repeat as needed."`
-		Out                   string        `name:"out" short:"o" default:"" help:"Starter config destination: omit to suppress it, use 'stdout' to print it, or provide a file path to write it."`
-		OutPath               string        `name:"out-path" default:"./generated" help:"OutPath to use in TOML output."`
-		OutPackagePath        string        `name:"out-package-path" default:"" help:"OutPackagePath to use in TOML output."`
-		GeneratedTypesPackage string        `name:"generated-types-package" default:"dbtypes" help:"PackageName to use for generated PostgreSQL wrapper types in TOML output."`
-		GeneratedTypesPath    string        `name:"generated-types-path" default:"models/dbtypes" help:"RelativePath to use for generated PostgreSQL wrapper types in TOML output."`
+		Logging       LoggingConfig             `embed:""`
+		Connection    PostgreSQLConnectionFlags `embed:""`
+		Objects       []string                  `name:"object" help:"Database object to inspect. Repeat to limit the inspection scope."`
+		StarterConfig StarterConfigFlags        `embed:""`
+		Out           string                    `name:"out" short:"o" default:"" help:"Starter config destination: omit to suppress it, use 'stdout' to print it, or provide a file path to write it."`
+	}
+
+	// GenerateConfigFromDBCmd writes a starter config from a live PostgreSQL
+	// database.
+	GenerateConfigFromDBCmd struct {
+		Logging       LoggingConfig             `embed:""`
+		Connection    PostgreSQLConnectionFlags `embed:""`
+		Objects       []string                  `name:"object" help:"Database object to include. Repeat to limit the config to these objects."`
+		StarterConfig StarterConfigFlags        `embed:""`
+		Out           string                    `name:"out" short:"o" default:"gormdb2struct.toml" help:"Path to write the config." type:"path"`
+		Force         bool                      `name:"force" help:"Overwrite the --out file when it already exists."`
 	}
 
 	// ConvertConfigCmd loads any supported config and emits the canonical
@@ -58,6 +81,8 @@ type (
 	CLIConfig struct {
 		Logging    LoggingConfig `embed:""`
 		ConfigPath string        `arg:"" optional:"" name:"config" help:"Path to the TOML configuration file." type:"path"`
+		PruneOnly  bool          `name:"prune-only" aliases:"pruneOnly" help:"Delete generated files whose table or view no longer exists and drop them from gen.go and zz_migrate.gen.go; nothing is regenerated."`
+		DryRun     bool          `name:"dry-run" aliases:"dryRun" help:"Print the objects and model names that would be generated; do not clean up or write files."`
 	}
 )
 
@@ -82,6 +107,14 @@ func buildGenerateConfigSampleParser(cmd *GenerateConfigSampleCmd) *kong.Kong {
 	)
 }
 
+func buildGenerateConfigFromDBParser(cmd *GenerateConfigFromDBCmd) *kong.Kong {
+	return kong.Must(cmd,
+		kong.Name(consts.APPNAME+" generate-config-from-db"),
+		kong.Description("Write a starter TOML configuration from a live PostgreSQL database, listing its objects and unmapped column types."),
+		kong.ShortUsageOnError(),
+	)
+}
+
 func buildInspectParser(cmd *InspectCmd) *kong.Kong {
 	return kong.Must(cmd,
 		kong.Name(consts.APPNAME+" inspect"),
@@ -13,7 +13,7 @@ func runConvertConfig(cmd ConvertConfigCmd) error {
 		return fmt.Errorf("--in-place and --out cannot be used together")
 	}
 
-	cfg, err := config.Load(cmd.ConfigPath)
+	cfg, err := config.LoadUnexpanded(cmd.ConfigPath)
 	if err != nil {
 		return err
 	}
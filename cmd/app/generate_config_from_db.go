@@ -0,0 +1,38 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"os"
+
+	"github.com/dan-sherwin/gormdb2struct/internal/generator"
+)
+
+func runGenerateConfigFromDB(ctx context.Context, cmd GenerateConfigFromDBCmd) error {
+	initLogger(cmd.Logging.Level)
+
+	if !cmd.Force {
+		if _, err := os.Stat(cmd.Out); err == nil {
+			return fmt.Errorf("config %s already exists; pass --force to overwrite it", cmd.Out)
+		} else if !os.IsNotExist(err) {
+			return fmt.Errorf("check config %s: %w", cmd.Out, err)
+		}
+	}
+
+	cfg, err := buildInspectPostgreSQLConfig(cmd.Connection, cmd.StarterConfig, cmd.Objects)
+	if err != nil {
+		return err
+	}
+
+	report, err := generator.New(slog.Default()).Inspect(ctx, cfg)
+	if err != nil {
+		return err
+	}
+
+	if err := os.WriteFile(cmd.Out, []byte(generator.RenderInspectionStarterConfig(cfg, report)), 0o644); err != nil {
+		return fmt.Errorf("write config %s: %w", cmd.Out, err)
+	}
+	_, err = fmt.Fprintf(os.Stdout, "Config for %d objects written to %s\n", len(report.Objects), cmd.Out)
+	return err
+}
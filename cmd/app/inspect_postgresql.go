@@ -14,7 +14,7 @@ import (
 func runInspectPostgreSQL(ctx context.Context, cmd InspectPostgreSQLCmd) error {
 	initLogger(cmd.Logging.Level)
 
-	cfg, err := buildInspectPostgreSQLConfig(cmd)
+	cfg, err := buildInspectPostgreSQLConfig(cmd.Connection, cmd.StarterConfig, cmd.Objects)
 	if err != nil {
 		return err
 	}
@@ -90,32 +90,32 @@ func inspectPostgreSQLOutputMode(out string) (inspectPostgreSQLOutputKind, strin
 	}
 }
 
-func buildInspectPostgreSQLConfig(cmd InspectPostgreSQLCmd) (config.Config, error) {
-	password, err := resolveInspectPostgreSQLPassword(cmd)
+func buildInspectPostgreSQLConfig(connection PostgreSQLConnectionFlags, starter StarterConfigFlags, objectNames []string) (config.Config, error) {
+	password, err := resolveInspectPostgreSQLPassword(connection)
 	if err != nil {
 		return config.Config{}, err
 	}
 
 	cfg := config.Config{
 		DatabaseDialect:    config.PostgreSQL,
-		OutPath:            cmd.OutPath,
-		OutPackagePath:     cmd.OutPackagePath,
-		ImportPackagePaths: append([]string(nil), cmd.ImportPackagePaths...),
+		OutPath:            starter.OutPath,
+		OutPackagePath:     starter.OutPackagePath,
+		ImportPackagePaths: append([]string(nil), starter.ImportPackagePaths...),
 		GeneratedTypes: config.GeneratedTypesConfig{
-			PackageName:  cmd.GeneratedTypesPackage,
-			RelativePath: cmd.GeneratedTypesPath,
+			PackageName:  starter.GeneratedTypesPackage,
+			RelativePath: starter.GeneratedTypesPath,
 		},
-		DbHost:     cmd.Host,
-		DbPort:     cmd.Port,
-		DbName:     cmd.Database,
-		DbUser:     cmd.User,
+		DbHost:     connection.Host,
+		DbPort:     connection.Port,
+		DbName:     connection.Database,
+		DbUser:     connection.User,
 		DbPassword: password,
-		DbSSLMode:  cmd.SSLMode,
+		DbSSLMode:  connection.SSLMode,
 		CleanUp:    true,
 	}
 
-	if len(cmd.Objects) > 0 {
-		objects := append([]string(nil), cmd.Objects...)
+	if len(objectNames) > 0 {
+		objects := append([]string(nil), objectNames...)
 		cfg.Objects = &objects
 	}
 
@@ -9,7 +9,7 @@ import (
 	"golang.org/x/term"
 )
 
-func resolveInspectPostgreSQLPassword(cmd InspectPostgreSQLCmd) (string, error) {
+func resolveInspectPostgreSQLPassword(cmd PostgreSQLConnectionFlags) (string, error) {
 	sources := 0
 	if cmd.Password != "" {
 		sources++
@@ -53,7 +53,10 @@ func resolveInspectPostgreSQLPassword(cmd InspectPostgreSQLCmd) (string, error)
 			return "", fmt.Errorf("read PostgreSQL password from prompt: %w", err)
 		}
 		return string(password), nil
-	default:
+	case cmd.Password != "":
 		return cmd.Password, nil
+	default:
+		// libpq falls back to PGPASSWORD when no password is given.
+		return os.Getenv("PGPASSWORD"), nil
 	}
 }
@@ -1,14 +1,17 @@
 package app
 
 import (
+	"context"
 	"os"
+	"path/filepath"
+	"strings"
 	"testing"
 )
 
 func TestResolveInspectPostgreSQLPasswordFromEnv(t *testing.T) {
 	t.Setenv("GORMDB2STRUCT_TEST_PASSWORD", "from-env")
 
-	password, err := resolveInspectPostgreSQLPassword(InspectPostgreSQLCmd{
+	password, err := resolveInspectPostgreSQLPassword(PostgreSQLConnectionFlags{
 		PasswordEnv: "GORMDB2STRUCT_TEST_PASSWORD",
 	})
 	if err != nil {
@@ -20,7 +23,7 @@ func TestResolveInspectPostgreSQLPasswordFromEnv(t *testing.T) {
 }
 
 func TestResolveInspectPostgreSQLPasswordRejectsMultipleSources(t *testing.T) {
-	_, err := resolveInspectPostgreSQLPassword(InspectPostgreSQLCmd{
+	_, err := resolveInspectPostgreSQLPassword(PostgreSQLConnectionFlags{
 		Password:    "inline",
 		PasswordEnv: "DB_PASSWORD",
 	})
@@ -45,7 +48,7 @@ func TestResolveInspectPostgreSQLPasswordFromStdin(t *testing.T) {
 		_ = w.Close()
 	}()
 
-	password, err := resolveInspectPostgreSQLPassword(InspectPostgreSQLCmd{
+	password, err := resolveInspectPostgreSQLPassword(PostgreSQLConnectionFlags{
 		PasswordStdin: true,
 	})
 	if err != nil {
@@ -70,7 +73,56 @@ func TestBuildInspectPostgreSQLParserCollectsImportPackages(t *testing.T) {
 		t.Fatalf("parse inspect-postgresql args: %v", err)
 	}
 
-	if len(cmd.ImportPackagePaths) != 2 || cmd.ImportPackagePaths[0] != "pkg/one" || cmd.ImportPackagePaths[1] != "pkg/two" {
-		t.Fatalf("unexpected import package paths: %#v", cmd.ImportPackagePaths)
+	if len(cmd.StarterConfig.ImportPackagePaths) != 2 || cmd.StarterConfig.ImportPackagePaths[0] != "pkg/one" || cmd.StarterConfig.ImportPackagePaths[1] != "pkg/two" {
+		t.Fatalf("unexpected import package paths: %#v", cmd.StarterConfig.ImportPackagePaths)
+	}
+}
+
+func TestGenerateConfigFromDBReadsLibpqEnvironment(t *testing.T) {
+	t.Setenv("PGHOST", "db.internal")
+	t.Setenv("PGDATABASE", "app")
+	t.Setenv("PGUSER", "reader")
+	t.Setenv("PGPASSWORD", "from-env")
+
+	cmd := GenerateConfigFromDBCmd{}
+	parser := buildGenerateConfigFromDBParser(&cmd)
+	if _, err := parser.Parse(nil); err != nil {
+		t.Fatalf("parse generate-config-from-db args: %v", err)
+	}
+
+	cfg, err := buildInspectPostgreSQLConfig(cmd.Connection, cmd.StarterConfig, cmd.Objects)
+	if err != nil {
+		t.Fatalf("build config: %v", err)
+	}
+	if cfg.DbHost != "db.internal" || cfg.DbName != "app" || cfg.DbUser != "reader" || cfg.DbPassword != "from-env" {
+		t.Fatalf("expected connection settings from the environment, got %+v", cfg)
+	}
+
+	cmd.Connection.Password = "explicit"
+	cfg, err = buildInspectPostgreSQLConfig(cmd.Connection, cmd.StarterConfig, cmd.Objects)
+	if err != nil {
+		t.Fatalf("build config with explicit password: %v", err)
+	}
+	if cfg.DbPassword != "explicit" {
+		t.Fatalf("expected --password to win over PGPASSWORD, got %q", cfg.DbPassword)
+	}
+}
+
+func TestGenerateConfigFromDBRefusesToOverwriteWithoutForce(t *testing.T) {
+	out := filepath.Join(t.TempDir(), "gormdb2struct.toml")
+	if err := os.WriteFile(out, []byte("# hand-edited\n"), 0o644); err != nil {
+		t.Fatalf("write existing config: %v", err)
+	}
+
+	err := runGenerateConfigFromDB(context.Background(), GenerateConfigFromDBCmd{Out: out})
+	if err == nil || !strings.Contains(err.Error(), "pass --force to overwrite it") {
+		t.Fatalf("expected an existing config to be refused, got %v", err)
+	}
+	content, err := os.ReadFile(out)
+	if err != nil {
+		t.Fatalf("read existing config: %v", err)
+	}
+	if string(content) != "# hand-edited\n" {
+		t.Fatalf("expected the existing config to be left alone, got %q", content)
 	}
 }
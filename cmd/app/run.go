@@ -26,6 +26,7 @@ func Run(ctx context.Context, args []string) error {
 	if handled {
 		return err
 	}
+	args = translateLegacyFlags(args)
 	handled, err = handleCommands(ctx, args)
 	if handled {
 		return err
@@ -43,18 +44,72 @@ func Run(ctx context.Context, args []string) error {
 	if strings.TrimSpace(cli.ConfigPath) == "" {
 		return errors.New("a config.toml path is required")
 	}
+	if cli.PruneOnly && cli.DryRun {
+		return errors.New("--prune-only and --dry-run cannot be used together")
+	}
 
-	cfg, err := config.Load(cli.ConfigPath)
+	cfgs, err := config.LoadAll(cli.ConfigPath)
 	if err != nil {
 		return err
 	}
 
-	slog.Debug("Loaded configuration",
-		slog.String("dialect", string(cfg.DatabaseDialect)),
-		slog.String("out_path", cfg.OutPath),
-	)
+	service := generator.New(slog.Default())
+	for _, cfg := range cfgs {
+		slog.Debug("Loaded configuration",
+			slog.String("dialect", string(cfg.DatabaseDialect)),
+			slog.String("out_path", cfg.OutPath),
+		)
+		if cli.PruneOnly {
+			if err := prune(ctx, service, cfg); err != nil {
+				if len(cfgs) > 1 {
+					return fmt.Errorf("prune %s: %w", cfg.OutPath, err)
+				}
+				return err
+			}
+			continue
+		}
+		if cli.DryRun {
+			if err := dryRun(ctx, service, cfg); err != nil {
+				if len(cfgs) > 1 {
+					return fmt.Errorf("dry run %s: %w", cfg.OutPath, err)
+				}
+				return err
+			}
+			continue
+		}
+		if err := service.Generate(ctx, cfg); err != nil {
+			if len(cfgs) > 1 {
+				return fmt.Errorf("generate %s: %w", cfg.OutPath, err)
+			}
+			return err
+		}
+	}
+	return nil
+}
+
+func prune(ctx context.Context, service *generator.Service, cfg config.Config) error {
+	removed, err := service.Prune(ctx, cfg)
+	for _, path := range removed {
+		slog.Info("Removed stale generated file", slog.String("path", path))
+	}
+	if err != nil {
+		return err
+	}
+	if len(removed) == 0 {
+		slog.Info("No stale generated files found", slog.String("out_path", cfg.OutPath))
+	}
+	return nil
+}
 
-	return generator.New(slog.Default()).Generate(ctx, cfg)
+func dryRun(ctx context.Context, service *generator.Service, cfg config.Config) error {
+	plan, err := service.Plan(ctx, cfg)
+	if err != nil {
+		return err
+	}
+	if _, err := fmt.Fprint(os.Stdout, generator.RenderGenerationPlan(plan)); err != nil {
+		return fmt.Errorf("write dry run plan: %w", err)
+	}
+	return nil
 }
 
 func handleTopLevelHelp(args []string) (bool, error) {
@@ -65,6 +120,7 @@ func handleTopLevelHelp(args []string) (bool, error) {
 	case "-h", "--help":
 		_, _ = fmt.Fprintf(os.Stdout, `Usage: %s <config> [flags]
        %s generate-config-sample [flags]
+       %s generate-config-from-db [flags]
        %s inspect <config> [flags]
        %s inspect-postgresql [flags]
 
@@ -75,6 +131,7 @@ Arguments:
 
 Commands:
   generate-config-sample    Write a commented starter TOML configuration file.
+  generate-config-from-db   Write a starter config listing the objects and unmapped types of a live PostgreSQL database.
   inspect                   Inspect PostgreSQL objects referenced by a config and recommend type mappings.
   inspect-postgresql        Inspect PostgreSQL directly from connection flags and optionally emit a starter config.
 
@@ -82,9 +139,11 @@ Flags:
   -h, --help                    Show context-sensitive help.
   -version, --version           Print version information.
       --logging.level="info"    Log level.
+  -prune-only, --prune-only     Delete generated files whose table or view no longer exists and drop them from gen.go and zz_migrate.gen.go; nothing is regenerated.
+  -dryRun, --dry-run            Print the objects and model names that would be generated; do not clean up or write files.
 
-Run "%s generate-config-sample --help", "%s inspect --help", or "%s inspect-postgresql --help" for command-specific help.
-`, consts.APPNAME, consts.APPNAME, consts.APPNAME, consts.APPNAME, consts.APPNAME, consts.APPNAME, consts.APPNAME)
+Run "%s generate-config-sample --help", "%s generate-config-from-db --help", "%s inspect --help", or "%s inspect-postgresql --help" for command-specific help.
+`, consts.APPNAME, consts.APPNAME, consts.APPNAME, consts.APPNAME, consts.APPNAME, consts.APPNAME, consts.APPNAME, consts.APPNAME, consts.APPNAME)
 		return true, nil
 	default:
 		return false, nil
@@ -107,12 +166,40 @@ func handleLegacyCompat(args []string) (bool, error) {
 	}
 }
 
+// legacyFlags maps the single-dash flag spellings of earlier releases, which
+// kong would read as a cluster of short flags, to their current long form.
+var legacyFlags = map[string]string{
+	"-prune-only": "--prune-only",
+	"-pruneOnly":  "--prune-only",
+	"-dry-run":    "--dry-run",
+	"-dryRun":     "--dry-run",
+}
+
+func translateLegacyFlags(args []string) []string {
+	translated := make([]string, len(args))
+	for i, arg := range args {
+		if flag, ok := legacyFlags[arg]; ok {
+			arg = flag
+		}
+		translated[i] = arg
+	}
+	return translated
+}
+
 func handleCommands(ctx context.Context, args []string) (bool, error) {
 	if len(args) == 0 {
 		return false, nil
 	}
 
 	switch args[0] {
+	case "generate-config-from-db", "-generateConfigFromDB":
+		cmd := GenerateConfigFromDBCmd{}
+		parser := buildGenerateConfigFromDBParser(&cmd)
+		if _, err := parser.Parse(args[1:]); err != nil {
+			return true, err
+		}
+
+		return true, runGenerateConfigFromDB(ctx, cmd)
 	case "generate-config-sample":
 		cmd := GenerateConfigSampleCmd{}
 		parser := buildGenerateConfigSampleParser(&cmd)
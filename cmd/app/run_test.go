@@ -5,8 +5,12 @@ import (
 	"context"
 	"os"
 	"path/filepath"
+	"reflect"
 	"strings"
 	"testing"
+
+	"github.com/glebarez/sqlite"
+	"gorm.io/gorm"
 )
 
 func TestRunGenerateConfigSampleCommandWritesSampleWithoutDeprecatedAliases(t *testing.T) {
@@ -37,7 +41,7 @@ func TestRunGenerateConfigSampleCommandWritesSampleWithoutDeprecatedAliases(t *t
 	if strings.Contains(sample, "\nDatabaseDialect =") ||
 		strings.Contains(sample, "\nGenerateDbInit =") ||
 		strings.Contains(sample, "\nTables =") ||
-		strings.Contains(sample, "MaterializedViews") ||
+		strings.Contains(sample, "\nMaterializedViews =") ||
 		strings.Contains(sample, "DomainTypeMap") {
 		t.Fatalf("expected sample config to omit legacy config keys")
 	}
@@ -81,7 +85,7 @@ func TestRunLegacyGenerateConfigSampleFlagStillWorks(t *testing.T) {
 	if strings.Contains(string(content), "\nDatabaseDialect =") ||
 		strings.Contains(string(content), "\nGenerateDbInit =") ||
 		strings.Contains(string(content), "\nTables =") ||
-		strings.Contains(string(content), "MaterializedViews") ||
+		strings.Contains(string(content), "\nMaterializedViews =") ||
 		strings.Contains(string(content), "DomainTypeMap") {
 		t.Fatalf("expected legacy sample output to omit legacy config keys")
 	}
@@ -103,6 +107,9 @@ func TestRunTopLevelHelpListsGenerateConfigSampleWithoutRunCommand(t *testing.T)
 	if !strings.Contains(output, "inspect-postgresql") {
 		t.Fatalf("expected top-level help to include inspect-postgresql command, got: %s", output)
 	}
+	if !strings.Contains(output, `"gormdb2struct generate-config-from-db --help"`) {
+		t.Fatalf("expected top-level help to point at generate-config-from-db help, got: %s", output)
+	}
 	if !strings.Contains(output, "-version, --version") {
 		t.Fatalf("expected top-level help to include version flags, got: %s", output)
 	}
@@ -159,7 +166,7 @@ DbHost = "invictus"
 DbPort = 5432
 DbName = "billing_core"
 DbUser = "billing_service"
-DbPassword = "secret"
+DbPassword = "${BILLING_DB_PASSWORD}"
 DbSSLMode = false
 GenerateDbInit = false
 IncludeAutoMigrate = false
@@ -196,6 +203,9 @@ CleanUp = true
 	if strings.Contains(output, "DomainTypeMap") || strings.Contains(output, "DatabaseDialect") {
 		t.Fatalf("expected legacy keys to be removed, got:\n%s", output)
 	}
+	if !strings.Contains(output, `Password = "${BILLING_DB_PASSWORD}"`) {
+		t.Fatalf("expected environment references to be kept verbatim, got:\n%s", output)
+	}
 }
 
 func TestRunConvertConfigInPlaceOverwritesInput(t *testing.T) {
@@ -239,6 +249,116 @@ CleanUp = true
 	}
 }
 
+func TestRunDryRunPrintsPlanWithoutWriting(t *testing.T) {
+	dir := t.TempDir()
+	dbPath := filepath.Join(dir, "dry_run.db")
+	db, err := gorm.Open(sqlite.Open(dbPath), &gorm.Config{})
+	if err != nil {
+		t.Fatalf("open SQLite fixture: %v", err)
+	}
+	for _, stmt := range []string{
+		`CREATE TABLE tickets (id INTEGER PRIMARY KEY, subject TEXT, total NUMERIC)`,
+		`CREATE VIEW open_tickets AS SELECT id, subject FROM tickets`,
+	} {
+		if err := db.Exec(stmt).Error; err != nil {
+			t.Fatalf("create fixture: %v", err)
+		}
+	}
+	sqlDB, err := db.DB()
+	if err != nil {
+		t.Fatalf("get sql.DB: %v", err)
+	}
+	_ = sqlDB.Close()
+
+	outPath := filepath.Join(dir, "generated")
+	existing := filepath.Join(outPath, "tickets.gen.go")
+	if err := os.MkdirAll(outPath, 0o755); err != nil {
+		t.Fatalf("mkdir out path: %v", err)
+	}
+	if err := os.WriteFile(existing, []byte("package generated\n"), 0o644); err != nil {
+		t.Fatalf("write existing generated file: %v", err)
+	}
+
+	configPath := filepath.Join(dir, "config.toml")
+	configContent := `
+ConfigVersion = 1
+
+[Generator]
+OutPath = "` + outPath + `"
+CleanUp = true
+Objects = ["tickets", "open_tickets"]
+
+[Database]
+Dialect = "sqlite"
+
+[Database.SQLite]
+Path = "` + dbPath + `"
+
+[ColumnTypeOverridesByTable."tickets"]
+total = "float64"
+
+[JSONTagOverridesByTable."tickets"]
+subject = "title"
+`
+	if err := os.WriteFile(configPath, []byte(configContent), 0o644); err != nil {
+		t.Fatalf("write config: %v", err)
+	}
+
+	output := captureStdout(t, func() {
+		if err := Run(context.Background(), []string{configPath, "-dryRun"}); err != nil {
+			t.Fatalf("run dry run: %v", err)
+		}
+	})
+
+	for _, want := range []string{
+		"CleanUp would remove the *gen.go files under " + outPath + "\n",
+		"Objects (2):\n",
+		"  tickets (table) -> Ticket\n",
+		"    JSONTagOverridesByTable: subject\n",
+		"    ColumnTypeOverridesByTable: total=float64\n",
+		"  open_tickets (view) -> OpenTicket\n",
+	} {
+		if !strings.Contains(output, want) {
+			t.Fatalf("expected dry run output to contain %q, got:\n%s", want, output)
+		}
+	}
+	if _, err := os.Stat(existing); err != nil {
+		t.Fatalf("expected the dry run to leave existing files alone: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(outPath, "models")); !os.IsNotExist(err) {
+		t.Fatalf("expected the dry run not to create the models directory, got %v", err)
+	}
+
+	missingPath := filepath.Join(dir, "dry_rnu.db")
+	missingConfig := strings.Replace(configContent, dbPath, missingPath, 1)
+	if err := os.WriteFile(configPath, []byte(missingConfig), 0o644); err != nil {
+		t.Fatalf("write config: %v", err)
+	}
+	if err := Run(context.Background(), []string{configPath, "--dry-run"}); err == nil {
+		t.Fatal("expected a dry run against a missing database file to fail")
+	}
+	if _, err := os.Stat(missingPath); !os.IsNotExist(err) {
+		t.Fatalf("expected the dry run not to create %s, got %v", missingPath, err)
+	}
+
+	unknownObjectConfig := strings.Replace(configContent, `"open_tickets"]`, `"open_tickets", "closed_tickets"]`, 1)
+	if err := os.WriteFile(configPath, []byte(unknownObjectConfig), 0o644); err != nil {
+		t.Fatalf("write config: %v", err)
+	}
+	err = Run(context.Background(), []string{configPath, "--dry-run"})
+	if err == nil || !strings.Contains(err.Error(), `SQLite object "closed_tickets" was not found`) {
+		t.Fatalf("expected a configured object missing from sqlite_master to fail the dry run, got %v", err)
+	}
+}
+
+func TestTranslateLegacyFlagsRewritesSingleDashSpellings(t *testing.T) {
+	got := translateLegacyFlags([]string{"config.toml", "-prune-only", "--logging.level", "debug"})
+	want := []string{"config.toml", "--prune-only", "--logging.level", "debug"}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("expected %v, got %v", want, got)
+	}
+}
+
 func captureStdout(t *testing.T, fn func()) string {
 	t.Helper()
 
@@ -3,6 +3,7 @@ package main
 
 import (
 	"os"
+	"os/exec"
 	"path/filepath"
 	"runtime"
 	"strings"
@@ -23,7 +24,7 @@ func TestPostgresDbInitTemplateNoDB(t *testing.T) {
 
 	// Create an OutPath under the project root so that package name resolution is simple.
 	outPath := filepath.Join(projectRootPG(t), "generated_pg_nodb")
-	if err := os.MkdirAll(outPath, 0o755); err != nil {
+	if err := os.MkdirAll(filepath.Join(outPath, "models"), 0o755); err != nil {
 		t.Fatal(err)
 	}
 	t.Cleanup(func() { _ = os.RemoveAll(outPath) })
@@ -51,7 +52,7 @@ func TestPostgresDbInitTemplateNoDB(t *testing.T) {
 	}
 
 	// Generate the db.go initializer using the template function.
-	if err := generator.WritePostgresDBInit(cfg, g); err != nil {
+	if err := generator.WritePostgresDBInit(cfg, g, nil); err != nil {
 		t.Fatalf("write postgres DbInit: %v", err)
 	}
 
@@ -69,15 +70,26 @@ func TestPostgresDbInitTemplateNoDB(t *testing.T) {
 	mustContain(t, content, "Code generated by gormdb2struct; DO NOT EDIT.")
 	mustContain(t, content, "package "+pkgBase)
 	mustContain(t, content, "\""+modulePathPG(t)+"/"+pkgBase+"/models\"")
-	// Ensure AutoMigrate contains our fake models
-	mustContain(t, content, "&models.Foo{}")
-	mustContain(t, content, "&models.Bar{}")
-	// Ensure DSN is constructed via go-utilities when optional DSN is not provided.
-	mustContain(t, content, "utilities.DbDSN(utilities.DbDSNConfig{")
-	mustContain(t, content, "\"github.com/dan-sherwin/go-utilities\"")
-	mustNotContain(t, content, "type DbDSNConfig struct")
-	mustNotContain(t, content, "func DbDSN(")
+	// Ensure DbInit delegates to the models package, which lists our fake models
+	mustContain(t, content, "models.AutoMigrate(gormDB)")
+	mustNotContain(t, content, "&models.Foo{}")
+	migrateFile, err := os.ReadFile(filepath.Join(outPath, "models", "zz_migrate.gen.go"))
+	if err != nil {
+		t.Fatalf("reading generated zz_migrate.gen.go: %v", err)
+	}
+	mustContain(t, string(migrateFile), "package models")
+	mustContain(t, string(migrateFile), "&Foo{}")
+	mustContain(t, string(migrateFile), "&Bar{}")
+	// Ensure DSN is constructed via the generated BuildDSN when optional DSN is not provided.
+	mustContain(t, content, "dsn, err = BuildDSN(DialectPostgreSQL, DSNConfig{")
+	mustNotContain(t, content, "go-utilities")
 	mustNotContain(t, content, "slog.")
+
+	dsnFile, err := os.ReadFile(filepath.Join(outPath, "dsn.go"))
+	if err != nil {
+		t.Fatalf("reading generated dsn.go: %v", err)
+	}
+	mustContain(t, string(dsnFile), "func BuildDSN(dialect string, cfg DSNConfig) (string, error) {")
 }
 
 func TestPostgresDbInitTemplateOptionalAppSettingsAndSlogGorm(t *testing.T) {
@@ -102,15 +114,16 @@ func TestPostgresDbInitTemplateOptionalAppSettingsAndSlogGorm(t *testing.T) {
 			GenerateAppSettingsRegistration: true,
 			UseSlogGormLogger:               true,
 		},
-		DbHost:     "db.example.local",
-		DbPort:     5432,
-		DbName:     "unit_test_db",
-		DbUser:     "test_user",
-		DbPassword: "secret",
-		DbSSLMode:  true,
+		DbHost:          "db.example.local",
+		DbPort:          5432,
+		DbName:          "unit_test_db",
+		DbUser:          "test_user",
+		DbPassword:      "secret",
+		DbSSLMode:       true,
+		DbSSLModeString: "verify-full",
 	}
 
-	if err := generator.WritePostgresDBInit(cfg, g); err != nil {
+	if err := generator.WritePostgresDBInit(cfg, g, nil); err != nil {
 		t.Fatalf("write postgres DbInit with optional features: %v", err)
 	}
 
@@ -123,11 +136,225 @@ func TestPostgresDbInitTemplateOptionalAppSettingsAndSlogGorm(t *testing.T) {
 	mustContain(t, content, `app_settings.RegisterStringSetting("dbHost", "Hostname of the database", &DbHost)`)
 	mustContain(t, content, `app_settings.RegisterIntSetting("dbPort", "Port of the database", &DbPort)`)
 	mustContain(t, content, `app_settings.RegisterBoolSetting("dbSSLMode", "Whether to require SSL for the database connection", &DbSSLMode)`)
+	mustContain(t, content, `DbSSLModeString = "verify-full"`)
+	mustContain(t, content, "SSLModeString: DbSSLModeString,")
 	mustContain(t, content, `"github.com/dan-sherwin/go-app-settings"`)
 	mustContain(t, content, `"github.com/orandin/slog-gorm"`)
 	mustContain(t, content, "Logger: slogGorm.New(),")
 }
 
+func TestPostgresDbInitTemplateNamespacePrefixesGlobals(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping postgres template test in short mode")
+	}
+
+	outPath := filepath.Join(projectRootPG(t), "generated_pg_nodb_namespace")
+	if err := os.MkdirAll(outPath, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = os.RemoveAll(outPath) })
+
+	g := gen.NewGenerator(gen.Config{
+		OutPath:      outPath,
+		ModelPkgPath: filepath.Join(outPath, "models"),
+	})
+	g.Data["Foo"] = nil
+
+	cfg := config.Config{
+		DbInit: config.GenerateDbInitConfig{
+			GenerateAppSettingsRegistration: true,
+			Namespace:                       "Analytics",
+			GenerateDBStats:                 true,
+		},
+		DbHost: "db.example.local",
+		DbPort: 5432,
+		DbName: "analytics",
+	}
+
+	if err := generator.WritePostgresDBInit(cfg, g, nil); err != nil {
+		t.Fatalf("write postgres DbInit with namespace: %v", err)
+	}
+
+	b, err := os.ReadFile(filepath.Join(outPath, "db.go"))
+	if err != nil {
+		t.Fatalf("reading generated db.go: %v", err)
+	}
+	content := string(b)
+
+	mustContain(t, content, "func AnalyticsDbInit(optionalDSN ...string) error {")
+	mustContain(t, content, "AnalyticsDB              *gorm.DB")
+	mustContain(t, content, "AnalyticsDB = gormDB")
+	mustContain(t, content, `app_settings.RegisterStringSetting("analyticsDbHost", "Hostname of the database", &AnalyticsDbHost)`)
+	mustContain(t, content, `app_settings.RegisterBoolSetting("analyticsDbSSLMode", "Whether to require SSL for the database connection", &AnalyticsDbSSLMode)`)
+	mustNotContain(t, content, "func DbInit(")
+
+	dsnFile, err := os.ReadFile(filepath.Join(outPath, "dsn.go"))
+	if err != nil {
+		t.Fatalf("reading generated dsn.go: %v", err)
+	}
+	mustContain(t, string(dsnFile), "func AnalyticsBuildDSN(dialect string, cfg AnalyticsDSNConfig) (string, error) {")
+	mustContain(t, string(dsnFile), "func analyticsQuoteDSNValue(value string) string {")
+
+	statsFile, err := os.ReadFile(filepath.Join(outPath, "db_stats.go"))
+	if err != nil {
+		t.Fatalf("reading generated db_stats.go: %v", err)
+	}
+	mustContain(t, string(statsFile), "func AnalyticsDBStats() (sql.DBStats, error) {")
+	mustContain(t, string(statsFile), "func AnalyticsDBHealth(ctx context.Context) (sql.DBStats, error) {")
+	mustContain(t, string(statsFile), "sqldb, err := AnalyticsDB.DB()")
+}
+
+func TestPostgresDbInitTemplateNamespacesShareOnePackage(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping postgres template test in short mode")
+	}
+
+	root := filepath.Join(projectRootPG(t), "generated_pg_nodb_shared")
+	t.Cleanup(func() { _ = os.RemoveAll(root) })
+	shared := filepath.Join(root, "dbs")
+	if err := os.MkdirAll(shared, 0o755); err != nil {
+		t.Fatal(err)
+	}
+
+	// Write each namespace into its own directory named like the shared
+	// package, then combine their self-contained dsn.go files into it.
+	for _, namespace := range []string{"Analytics", "Billing"} {
+		outPath := filepath.Join(root, namespace, "dbs")
+		if err := os.MkdirAll(outPath, 0o755); err != nil {
+			t.Fatal(err)
+		}
+		g := gen.NewGenerator(gen.Config{
+			OutPath:      outPath,
+			ModelPkgPath: filepath.Join(outPath, "models"),
+		})
+		g.Data["Foo"] = nil
+		cfg := config.Config{
+			DbInit: config.GenerateDbInitConfig{Namespace: namespace},
+			DbHost: "db.example.local",
+			DbPort: 5432,
+			DbName: strings.ToLower(namespace),
+		}
+		if err := generator.WritePostgresDBInit(cfg, g, nil); err != nil {
+			t.Fatalf("write postgres DbInit for %s: %v", namespace, err)
+		}
+		content, err := os.ReadFile(filepath.Join(outPath, "dsn.go"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(filepath.Join(shared, strings.ToLower(namespace)+"_dsn.go"), content, 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	vet := exec.Command("go", "vet", "./generated_pg_nodb_shared/dbs")
+	vet.Dir = projectRootPG(t)
+	vet.Env = os.Environ()
+	if out, err := vet.CombinedOutput(); err != nil {
+		t.Fatalf("expected two namespaces to share a package: %v\n%s", err, out)
+	}
+}
+
+func TestPostgresDbInitTemplateCreateDatabaseIfMissing(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping postgres template test in short mode")
+	}
+
+	outPath := filepath.Join(projectRootPG(t), "generated_pg_nodb_create_db")
+	if err := os.MkdirAll(outPath, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = os.RemoveAll(outPath) })
+
+	g := gen.NewGenerator(gen.Config{
+		OutPath:      outPath,
+		ModelPkgPath: filepath.Join(outPath, "models"),
+	})
+	g.Data["Foo"] = nil
+
+	cfg := config.Config{
+		DbInit: config.GenerateDbInitConfig{
+			CreateDatabaseIfMissing: true,
+		},
+		DbHost: "db.example.local",
+		DbPort: 5432,
+		DbName: "unit_test_db",
+	}
+
+	if err := generator.WritePostgresDBInit(cfg, g, nil); err != nil {
+		t.Fatalf("write postgres DbInit with CreateDatabaseIfMissing: %v", err)
+	}
+
+	b, err := os.ReadFile(filepath.Join(outPath, "db.go"))
+	if err != nil {
+		t.Fatalf("reading generated db.go: %v", err)
+	}
+	content := string(b)
+
+	mustContain(t, content, "func CreateDatabaseIfMissing() error {")
+	mustContain(t, content, `Name:          "postgres",`)
+	mustContain(t, content, "SELECT EXISTS (SELECT 1 FROM pg_database WHERE datname = ?)")
+	mustContain(t, content, `maintenanceDB.Exec("CREATE DATABASE ?", clause.Table{Name: DbName})`)
+	mustContain(t, content, "if err := CreateDatabaseIfMissing(); err != nil {")
+}
+
+func TestPostgresDbInitTemplateReadsEnvReferencesAtRuntime(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping postgres template test in short mode")
+	}
+	t.Setenv("GORMDB2STRUCT_TEST_PGPASSWORD", "s3cr3t-value")
+	t.Setenv("GORMDB2STRUCT_TEST_PGHOST", "db.internal")
+
+	outPath := filepath.Join(projectRootPG(t), "generated_pg_nodb_env")
+	if err := os.MkdirAll(outPath, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = os.RemoveAll(outPath) })
+
+	cfgPath := filepath.Join(t.TempDir(), "config.toml")
+	if err := os.WriteFile(cfgPath, []byte(`
+ConfigVersion = 1
+
+[Generator]
+OutPath = "`+outPath+`"
+
+[Database]
+Dialect = "postgresql"
+
+[Database.PostgreSQL]
+Host = "${GORMDB2STRUCT_TEST_PGHOST}"
+Name = "unit_test_db"
+User = "test_user"
+Password = "${GORMDB2STRUCT_TEST_PGPASSWORD}"
+`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	cfg, err := config.Load(cfgPath)
+	if err != nil {
+		t.Fatalf("load config: %v", err)
+	}
+
+	g := gen.NewGenerator(gen.Config{
+		OutPath:      outPath,
+		ModelPkgPath: filepath.Join(outPath, "models"),
+	})
+	if err := generator.WritePostgresDBInit(cfg, g, nil); err != nil {
+		t.Fatalf("write postgres DbInit: %v", err)
+	}
+
+	b, err := os.ReadFile(filepath.Join(outPath, "db.go"))
+	if err != nil {
+		t.Fatalf("reading generated db.go: %v", err)
+	}
+	content := string(b)
+
+	mustNotContain(t, content, "s3cr3t-value")
+	mustNotContain(t, content, "db.internal")
+	mustContain(t, content, `"os"`)
+	mustContain(t, content, `DbHost     = os.Getenv("GORMDB2STRUCT_TEST_PGHOST")`)
+	mustContain(t, content, `DbPassword = os.Getenv("GORMDB2STRUCT_TEST_PGPASSWORD")`)
+	mustContain(t, content, `DbUser     = "test_user"`)
+}
+
 func mustContain(t *testing.T, s, sub string) {
 	t.Helper()
 	if !strings.Contains(s, sub) {
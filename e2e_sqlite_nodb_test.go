@@ -35,7 +35,7 @@ func TestSQLiteDbInitTemplateOptionalAppSettingsAndSlogGorm(t *testing.T) {
 		},
 	}
 
-	if err := generator.WriteSQLiteDBInit(cfg, g); err != nil {
+	if err := generator.WriteSQLiteDBInit(cfg, g, nil); err != nil {
 		t.Fatalf("write sqlite DbInit with optional features: %v", err)
 	}
 
@@ -51,3 +51,42 @@ func TestSQLiteDbInitTemplateOptionalAppSettingsAndSlogGorm(t *testing.T) {
 	mustContain(t, content, "Logger: slogGorm.New(),")
 	mustNotContain(t, content, "slog.")
 }
+
+func TestSQLiteDbInitTemplateConfiguresSlogDefault(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping sqlite template test in short mode")
+	}
+
+	outPath := filepath.Join(projectRoot(t), "generated_sqlite_nodb_logging")
+	if err := os.MkdirAll(outPath, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = os.RemoveAll(outPath) })
+
+	g := gen.NewGenerator(gen.Config{
+		OutPath:      outPath,
+		ModelPkgPath: filepath.Join(outPath, "models"),
+	})
+	g.Data["Foo"] = nil
+
+	cfg := config.Config{
+		SQLiteDBPath: "./example.db",
+		DbInit: config.GenerateDbInitConfig{
+			LogFormat: "json",
+			LogLevel:  "debug",
+		},
+	}
+
+	if err := generator.WriteSQLiteDBInit(cfg, g, nil); err != nil {
+		t.Fatalf("write sqlite DbInit with logging: %v", err)
+	}
+
+	b, err := os.ReadFile(filepath.Join(outPath, "db_sqlite.go"))
+	if err != nil {
+		t.Fatalf("reading generated db_sqlite.go: %v", err)
+	}
+	content := string(b)
+
+	mustContain(t, content, `"log/slog"`)
+	mustContain(t, content, "slog.SetDefault(slog.New(slog.NewJSONHandler(os.Stderr, &slog.HandlerOptions{Level: slog.LevelDebug})))")
+}
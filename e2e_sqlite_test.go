@@ -64,6 +64,33 @@ func TestEndToEndSQLite(t *testing.T) {
 			name TEXT,
 			FOREIGN KEY(all_types_id) REFERENCES all_types(id)
 		);`,
+		// unique index to exercise FindOrCreate helpers
+		`CREATE TABLE IF NOT EXISTS label (
+			id INTEGER PRIMARY KEY,
+			name TEXT NOT NULL,
+			color TEXT
+		);`,
+		`CREATE UNIQUE INDEX IF NOT EXISTS label_name_key ON label(name);`,
+		// view to exercise read-only fields and its exclusion from AutoMigrate
+		`CREATE VIEW IF NOT EXISTS label_view AS SELECT id, name FROM label;`,
+		// tenant column to exercise ScopeByTenant and tenant enforcement
+		`CREATE TABLE IF NOT EXISTS note (
+			id INTEGER PRIMARY KEY,
+			tenant_id INTEGER NOT NULL,
+			body TEXT
+		);`,
+		// self-referential foreign key to exercise DetectForeignKeys
+		`CREATE TABLE IF NOT EXISTS category (
+			id INTEGER PRIMARY KEY,
+			parent_id INTEGER REFERENCES category(id),
+			name TEXT NOT NULL
+		);`,
+		// deleted_at column to exercise soft deletes through the query objects
+		`CREATE TABLE IF NOT EXISTS task (
+			id INTEGER PRIMARY KEY,
+			title TEXT NOT NULL,
+			deleted_at DATETIME
+		);`,
 	}
 	for _, q := range schema {
 		if _, err := db.Exec(q); err != nil {
@@ -85,6 +112,10 @@ ConfigVersion = 1
 [Generator]
 OutPath = %q
 CleanUp = true
+ModelFileNamePattern = "{{.Table}}.model"
+QueryInterfaceTables = ["label"]
+Objects = ["all_types", "category", "child", "label", "label_view", "note", "task"]
+DetectForeignKeys = true
 
 [Database]
 Dialect = "sqlite"
@@ -95,6 +126,26 @@ Path = %q
 [DbInit]
 Enabled = true
 IncludeAutoMigrate = true
+GenerateDBStats = true
+LogFormat = "json"
+LogLevel = "warn"
+
+[Helpers]
+GeneratePreloadConstants = true
+GenerateSchemaDoc = true
+GenerateFindOrCreate = true
+GenerateSchemaAssertion = true
+GenerateScopes = true
+GenerateUpdateHelpers = true
+GenerateERD = true
+GenerateExists = true
+GenerateMapConversion = true
+GenerateTxHelpers = true
+TenantColumn = "tenant_id"
+EnforceTenant = true
+
+[SensitiveColumns]
+"child" = ["name"]
 
 [ExtraFields]
   [[ExtraFields."all_types"]]
@@ -122,6 +173,36 @@ IncludeAutoMigrate = true
 	// Verify expected generated files exist
 	mustExist(t, filepath.Join(outPath, "models"))
 	mustExist(t, filepath.Join(outPath, "db_sqlite.go"))
+	mustExist(t, filepath.Join(outPath, "SCHEMA.md"))
+	erd, err := os.ReadFile(filepath.Join(outPath, "schema_gen.dot"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(erd), `"child" -> "all_types" [label="all_types_id"];`) {
+		t.Fatalf("expected ERD to contain the child foreign key edge:\n%s", erd)
+	}
+	mustExist(t, filepath.Join(outPath, "models", "all_types.model.gen.go"))
+	labelView, err := os.ReadFile(filepath.Join(outPath, "models", "label_view.model.gen.go"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(labelView), `gorm:"column:name;type:TEXT;->"`) {
+		t.Fatalf("expected view columns to be read-only:\n%s", labelView)
+	}
+	task, err := os.ReadFile(filepath.Join(outPath, "models", "task.model.gen.go"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(task), "DeletedAt gorm.DeletedAt") {
+		t.Fatalf("expected deleted_at to be modeled as gorm.DeletedAt:\n%s", task)
+	}
+	migrate, err := os.ReadFile(filepath.Join(outPath, "models", "zz_migrate.gen.go"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(migrate), "&Label{}") || strings.Contains(string(migrate), "LabelView") {
+		t.Fatalf("expected models.AutoMigrate to list tables but not views:\n%s", migrate)
+	}
 
 	// Determine the generated struct name for the all_types table by reading its model file
 	modelsDir := filepath.Join(outPath, "models")
@@ -169,7 +250,10 @@ IncludeAutoMigrate = true
 	t.Cleanup(func() { _ = os.RemoveAll(cmdDir) })
 	mainGo := fmt.Sprintf(`package main
 import (
+  "context"
+  "errors"
   "fmt"
+  "strings"
   "time"
   "gorm.io/datatypes"
   g "%s/%s"
@@ -177,6 +261,13 @@ import (
 )
 func main(){
   if err := g.DbInit(%q); err != nil { panic(err) }
+  if err := m.AssertSchema(g.DB); err != nil { panic(err) }
+  if stats, err := g.DBHealth(context.Background()); err != nil || stats.OpenConnections < 1 { panic(fmt.Sprintf("unexpected DBHealth: %%+v %%v", stats, err)) }
+  if stats, err := g.DBStats(); err != nil || stats.OpenConnections < 1 { panic(fmt.Sprintf("unexpected DBStats: %%+v %%v", stats, err)) }
+  if dsn, err := g.BuildDSN(g.DialectPostgreSQL, g.DSNConfig{Host: "db", Port: 5432, Name: "app db", Password: `+"`"+`it's`+"`"+`}); err != nil || dsn != `+"`"+`host=db dbname='app db' port=5432 password='it\'s' sslmode=disable`+"`"+` { panic(fmt.Sprintf("unexpected postgres DSN: %%q %%v", dsn, err)) }
+  if _, err := g.BuildDSN("oracle", g.DSNConfig{}); err == nil { panic("expected an unsupported dialect error") }
+  if dsn, err := g.BuildDSN(g.DialectPostgreSQL, g.DSNConfig{Host: "db", Name: "app", SSLMode: true}); err != nil || !strings.HasSuffix(dsn, " sslmode=require") { panic(fmt.Sprintf("unexpected SSLMode DSN: %%q %%v", dsn, err)) }
+  if dsn, err := g.BuildDSN(g.DialectPostgreSQL, g.DSNConfig{Host: "db", Name: "app", SSLMode: true, SSLModeString: "verify-full"}); err != nil || !strings.HasSuffix(dsn, " sslmode=verify-full") { panic(fmt.Sprintf("unexpected SSLModeString DSN: %%q %%v", dsn, err)) }
   // Insert
   js := datatypes.JSON([]byte(`+"`"+`{"a":1,"b":2}`+"`"+`))
   a := &m.%s{BoolCol: ptrBool(true), Tiny1: ptrStr("1"), IntCol: ptrI64(42), BigCol: ptrI64(4200), RealCol: ptrF64(1.5), DoubleCol: ptrF64(2.5), FloatCol: ptrF32(3.5), TextCol: ptrStr("hello"), VarcharCol: ptrStr("v"), CharCol: ptrStr("c"), BlobCol: ptrBytes([]byte{1,2,3}), DateCol: ptrTime(1700000000), DatetimeCol: ptrTime(1700000100), TsCol: ptrTime(1700000200), NumericCol: ptrF64(10.5), DecimalCol: ptrF64(20.5), DurationCol: ptrDur(1234567890), JSONCol: &js}
@@ -208,9 +299,47 @@ func main(){
     "json_col": &jsu,
   }).Error; err != nil { panic(err) }
   var after m.%s
-  if err := g.DB.First(&after, a.ID).Error; err != nil { panic(err) }
+  if err := g.DB.Preload(m.%sRels.Children.String()).First(&after, a.ID).Error; err != nil { panic(err) }
   if after.TextCol == nil || *after.TextCol != "world" { panic(fmt.Sprintf("unexpected text: %%v", after.TextCol)) }
   if after.JSONCol == nil || string(*after.JSONCol) != "\"scalar\"" { panic(fmt.Sprintf("unexpected json: %%v", after.JSONCol)) }
+  if s := fmt.Sprint(m.Child{Name: ptrStr("secret")}); !strings.Contains(s, "Name:[REDACTED]") || strings.Contains(s, "secret") { panic("sensitive column leaked: " + s) }
+  l1, created, err := m.FindOrCreateLabel(g.DB, m.Label{Name: ptrStr("urgent")}, m.Label{Color: ptrStr("red")})
+  if err != nil || !created || l1.Color == nil || *l1.Color != "red" { panic(fmt.Sprintf("unexpected first FindOrCreate: %%+v %%v %%v", l1, created, err)) }
+  l2, created, err := m.FindOrCreateLabel(g.DB, m.Label{Name: ptrStr("urgent")}, m.Label{Color: ptrStr("blue")})
+  if err != nil || created || l1.ID == nil || l2.ID == nil || *l2.ID != *l1.ID { panic(fmt.Sprintf("unexpected second FindOrCreate: %%+v %%v %%v", l2, created, err)) }
+  var labelViews []m.LabelView
+  if err := g.DB.Find(&labelViews).Error; err != nil || len(labelViews) != 1 || labelViews[0].Name == nil || *labelViews[0].Name != "urgent" { panic(fmt.Sprintf("unexpected label_view rows: %%+v %%v", labelViews, err)) }
+  if ok, err := m.LabelExistsByName(g.DB, "urgent"); err != nil || !ok { panic(fmt.Sprintf("expected label to exist: %%v %%v", ok, err)) }
+  if ok, err := m.LabelExistsByName(g.DB, "missing"); err != nil || ok { panic(fmt.Sprintf("expected label not to exist: %%v %%v", ok, err)) }
+  if lm := l1.ToMap(); lm["name"] != l1.Name || lm["color"] != l1.Color { panic(fmt.Sprintf("unexpected ToMap: %%v", lm)) }
+  fm, err := m.%sFromMap(map[string]any{"id": float64(7), "int_col": float64(3), "date_col": "2024-01-02T03:04:05Z", "json_col": map[string]any{"k": "v"}, "text_col": nil})
+  if err != nil || fm.ID == nil || *fm.ID != 7 || fm.IntCol == nil || *fm.IntCol != 3 || fm.DateCol == nil || fm.DateCol.Year() != 2024 || fm.JSONCol == nil || string(*fm.JSONCol) != `+"`"+`{"k":"v"}`+"`"+` || fm.TextCol != nil { panic(fmt.Sprintf("unexpected FromMap: %%+v %%v", fm, err)) }
+  if _, err := m.%sFromMap(map[string]any{"int_col": 1.5}); err == nil { panic("expected a fractional int_col to be rejected") }
+  if _, err := m.LabelFromMap(map[string]any{"nope": 1}); !errors.Is(err, m.ErrUnknownMapColumn) { panic(fmt.Sprintf("expected unknown column error, got %%v", err)) }
+  root := &m.Category{Name: "root"}
+  if err := g.DB.Create(root).Error; err != nil { panic(err) }
+  if err := g.DB.Create(&m.Category{Name: "leaf", ParentID: root.ID}).Error; err != nil { panic(err) }
+  var leaf m.Category
+  if p := m.CategoryRels.ParentCategories.Parent().String(); p != "ParentCategories.Parent" { panic("unexpected nested preload path: " + p) }
+  if err := g.DB.Preload(m.CategoryRels.Parent.String()).Where("name = ?", "leaf").First(&leaf).Error; err != nil || leaf.Parent == nil || leaf.Parent.Name != "root" { panic(fmt.Sprintf("unexpected leaf parent: %%+v %%v", leaf, err)) }
+  var tree m.Category
+  if err := g.DB.Preload("ParentCategories").First(&tree, root.ID).Error; err != nil || len(tree.ParentCategories) != 1 || tree.ParentCategories[0].Name != "leaf" { panic(fmt.Sprintf("unexpected root children: %%+v %%v", tree, err)) }
+  var kid m.Child
+  if err := g.DB.Create(&m.Child{AllTypesID: a.ID, Name: ptrStr("kid")}).Error; err != nil { panic(err) }
+  if err := g.DB.Preload("AllTypes").First(&kid).Error; err != nil || kid.AllTypes == nil || kid.AllTypes.ID == nil || *kid.AllTypes.ID != *a.ID { panic(fmt.Sprintf("unexpected child parent: %%+v %%v", kid, err)) }
+  if err := g.Task.Create(&m.Task{Title: ptrStr("keep")}, &m.Task{Title: ptrStr("drop")}); err != nil { panic(err) }
+  if _, err := g.Task.Where(g.Task.Title.Eq("drop")).Delete(); err != nil { panic(err) }
+  if tasks, err := g.Task.Find(); err != nil || len(tasks) != 1 || tasks[0].Title == nil || *tasks[0].Title != "keep" { panic(fmt.Sprintf("expected soft-deleted tasks to be excluded: %%+v %%v", tasks, err)) }
+  if n, err := g.Task.Unscoped().Count(); err != nil || n != 2 { panic(fmt.Sprintf("expected Unscoped to include soft-deleted tasks: %%d %%v", n, err)) }
+  if err := m.RegisterTenantEnforcement(g.DB); err != nil { panic(err) }
+  tenant1 := m.WithTenant(context.Background(), int64(1))
+  if err := g.DB.WithContext(tenant1).Create(&m.Note{Body: ptrStr("first")}).Error; err != nil { panic(err) }
+  var notes []m.Note
+  if err := g.DB.Find(&notes).Error; !errors.Is(err, m.ErrMissingTenant) { panic(fmt.Sprintf("expected missing tenant error, got %%v", err)) }
+  if err := g.DB.WithContext(m.WithTenant(context.Background(), int64(2))).Find(&notes).Error; err != nil || len(notes) != 0 { panic(fmt.Sprintf("tenant 2 saw tenant 1 notes: %%v %%v", notes, err)) }
+  if err := g.DB.WithContext(tenant1).Scopes(m.ScopeByTenant(1)).Find(&notes).Error; err != nil || len(notes) != 1 || notes[0].TenantID == nil || *notes[0].TenantID != 1 { panic(fmt.Sprintf("unexpected tenant 1 notes: %%+v %%v", notes, err)) }
+  if err := g.DB.Exec("ALTER TABLE label DROP COLUMN color").Error; err != nil { panic(err) }
+  if err := m.AssertSchema(g.DB); err == nil || !strings.Contains(err.Error(), "color") { panic(fmt.Sprintf("expected schema drift to be reported, got %%v", err)) }
   fmt.Print("OK")
 }
 func ptrStr(s string)*string{ return &s }
@@ -221,7 +350,7 @@ func ptrBool(v bool)*bool{ return &v }
 func ptrBytes(b []byte)*[]byte{ return &b }
 func ptrTime(sec int64)*time.Time{ t:=time.Unix(sec,0); return &t }
 func ptrDur(n int64)*time.Duration{ d:=time.Duration(n); return &d }
-`, modulePath(t), pkgBase, modulePath(t), pkgBase, dbPath, modelType, modelType, modelType)
+`, modulePath(t), pkgBase, modulePath(t), pkgBase, dbPath, modelType, modelType, modelType, modelType, modelType, modelType)
 	if err := os.WriteFile(filepath.Join(cmdDir, "main.go"), []byte(mainGo), 0o644); err != nil {
 		t.Fatal(err)
 	}
@@ -237,6 +366,75 @@ func ptrDur(n int64)*time.Duration{ d:=time.Duration(n); return &d }
 	if !strings.Contains(string(progOut), "OK") {
 		t.Fatalf("unexpected output: %s", string(progOut))
 	}
+
+	// Run the transaction helpers as a testable example in the generated
+	// query package, the way the README shows them. DbInit migrates a fresh
+	// database so the example does not depend on what the program above left.
+	exampleGo := fmt.Sprintf(`package %s_test
+
+import (
+	"errors"
+	"fmt"
+
+	"gorm.io/gorm"
+	g "%s/%s"
+	m "%s/%s/models"
+)
+
+func Example_transactions() {
+	if err := g.DbInit(%q); err != nil {
+		panic(err)
+	}
+
+	// Commit: Q.ReplaceDB binds every query object to the transaction.
+	if err := g.DB.Transaction(func(tx *gorm.DB) error {
+		q := g.Q.ReplaceDB(tx)
+		if err := q.Category.Create(&m.Category{Name: "committed"}); err != nil {
+			return err
+		}
+		title := "committed"
+		return q.Task.Create(&m.Task{Title: &title})
+	}); err != nil {
+		panic(err)
+	}
+
+	// Roll back: WithTx binds a single query object to the transaction.
+	errRollback := errors.New("rollback")
+	err := g.DB.Transaction(func(tx *gorm.DB) error {
+		if err := g.Category.WithTx(tx).Create(&m.Category{Name: "rolled back"}); err != nil {
+			return err
+		}
+		title := "rolled back"
+		if err := g.Task.WithTx(tx).Create(&m.Task{Title: &title}); err != nil {
+			return err
+		}
+		return errRollback
+	})
+	fmt.Println(errors.Is(err, errRollback))
+
+	categories, err := g.Category.Where(g.Category.Name.In("committed", "rolled back")).Count()
+	if err != nil {
+		panic(err)
+	}
+	tasks, err := g.Task.Where(g.Task.Title.In("committed", "rolled back")).Count()
+	if err != nil {
+		panic(err)
+	}
+	fmt.Println(categories, tasks)
+	// Output:
+	// true
+	// 1 1
+}
+`, pkgBase, modulePath(t), pkgBase, modulePath(t), pkgBase, filepath.Join(tmpDir, "tx.db"))
+	if err := os.WriteFile(filepath.Join(outPath, "tx_example_test.go"), []byte(exampleGo), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	example := exec.Command("go", "test", "-run", "^Example_transactions$", "./"+pkgBase)
+	example.Dir = projectRoot(t)
+	example.Env = os.Environ()
+	if exampleOut, err := example.CombinedOutput(); err != nil {
+		t.Fatalf("transaction example failed: %v\nOutput:\n%s", err, string(exampleOut))
+	}
 }
 
 func mustExist(t *testing.T, p string) {
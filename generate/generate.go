@@ -0,0 +1,37 @@
+// Package generate is the library entry point for gormdb2struct. It loads the
+// same TOML configs as the CLI and runs the same generation, and lets callers
+// set the hooks that TOML cannot express, such as Config.TransformModels.
+package generate
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/dan-sherwin/gormdb2struct/internal/config"
+	"github.com/dan-sherwin/gormdb2struct/internal/generator"
+)
+
+type (
+	// Config is the effective configuration for one database.
+	Config = config.Config
+	// GeneratedModel is the mutable view of one model passed to
+	// Config.TransformModels.
+	GeneratedModel = config.GeneratedModel
+)
+
+// Load reads a config that describes exactly one database, resolving ${VAR}
+// references and validating it like the CLI does.
+func Load(path string) (Config, error) {
+	return config.Load(path)
+}
+
+// LoadAll reads a config and returns one Config per database.
+func LoadAll(path string) ([]Config, error) {
+	return config.LoadAll(path)
+}
+
+// Run generates the models, query code, and helper files for cfg. A nil
+// logger logs to slog.Default().
+func Run(ctx context.Context, logger *slog.Logger, cfg Config) error {
+	return generator.New(logger).Generate(ctx, cfg)
+}
@@ -0,0 +1,85 @@
+package generate_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/dan-sherwin/gormdb2struct/generate"
+	"github.com/glebarez/sqlite"
+	"gorm.io/gorm"
+)
+
+func TestRunAppliesTransformModels(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	dbPath := filepath.Join(dir, "transform.db")
+	db, err := gorm.Open(sqlite.Open(dbPath), &gorm.Config{})
+	if err != nil {
+		t.Fatalf("open SQLite fixture: %v", err)
+	}
+	if err := db.Exec(`CREATE TABLE widget (id INTEGER PRIMARY KEY, code TEXT NOT NULL, legacy_flag INTEGER)`).Error; err != nil {
+		t.Fatalf("create fixture: %v", err)
+	}
+	sqlDB, err := db.DB()
+	if err != nil {
+		t.Fatalf("get sql.DB: %v", err)
+	}
+	_ = sqlDB.Close()
+
+	outPath := filepath.Join(dir, "generated")
+	configPath := filepath.Join(dir, "config.toml")
+	if err := os.WriteFile(configPath, []byte(`
+ConfigVersion = 1
+
+[Generator]
+OutPath = "`+outPath+`"
+
+[Database]
+Dialect = "sqlite"
+
+[Database.SQLite]
+Path = "`+dbPath+`"
+`), 0o644); err != nil {
+		t.Fatalf("write config: %v", err)
+	}
+
+	cfg, err := generate.Load(configPath)
+	if err != nil {
+		t.Fatalf("load config: %v", err)
+	}
+	var seen []string
+	cfg.TransformModels = func(models []*generate.GeneratedModel) {
+		for _, model := range models {
+			seen = append(seen, model.TableName+"="+model.StructName)
+			for i, fld := range model.Fields {
+				switch fld.ColumnName {
+				case "code":
+					fld.Name = "SKU"
+				case "legacy_flag":
+					model.Fields[i] = nil
+				}
+			}
+		}
+	}
+	if err := generate.Run(context.Background(), nil, cfg); err != nil {
+		t.Fatalf("run generation: %v", err)
+	}
+
+	if strings.Join(seen, ",") != "widget=Widget" {
+		t.Fatalf("expected TransformModels to see the widget model, got %v", seen)
+	}
+	content, err := os.ReadFile(filepath.Join(outPath, "models", "widget.gen.go"))
+	if err != nil {
+		t.Fatalf("read generated model: %v", err)
+	}
+	if !strings.Contains(string(content), "SKU ") {
+		t.Fatalf("expected the renamed field in the model, got:\n%s", content)
+	}
+	if strings.Contains(string(content), "legacy_flag") {
+		t.Fatalf("expected the dropped field to be left out of the model, got:\n%s", content)
+	}
+}
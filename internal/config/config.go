@@ -2,10 +2,14 @@ package config
 
 import (
 	"fmt"
+	"go/token"
 	"path"
 	"path/filepath"
+	"slices"
 	"strings"
+	"text/template"
 
+	"gorm.io/gen"
 	"gorm.io/gorm/schema"
 )
 
@@ -16,6 +20,34 @@ const (
 	SQLite     DatabaseDialect = "sqlite"
 )
 
+// MoneyType selects the Go representation of PostgreSQL money columns.
+type MoneyType string
+
+const (
+	MoneyTypeString     MoneyType = "string"
+	MoneyTypeInt64Cents MoneyType = "int64cents"
+	MoneyTypeDecimal    MoneyType = "decimal"
+)
+
+// JSONTagStrategy selects how json tag names are derived from column names
+// and ExtraFields struct property names.
+type JSONTagStrategy string
+
+const (
+	JSONTagStrategyCamel  JSONTagStrategy = "camel"
+	JSONTagStrategySnake  JSONTagStrategy = "snake"
+	JSONTagStrategyPascal JSONTagStrategy = "pascal"
+	JSONTagStrategyNone   JSONTagStrategy = "none"
+)
+
+// CatalogSource selects which PostgreSQL catalog generation introspects.
+type CatalogSource string
+
+const (
+	CatalogSourceInformationSchema CatalogSource = "information_schema"
+	CatalogSourcePgCatalog         CatalogSource = "pg_catalog"
+)
+
 type configSourceFormat uint8
 
 const (
@@ -25,26 +57,79 @@ const (
 )
 
 type Config struct {
-	DatabaseDialect         DatabaseDialect
-	OutPath                 string
-	OutPackagePath          string
-	ImportPackagePaths      []string
-	Objects                 *[]string
+	DatabaseDialect          DatabaseDialect
+	OutPath                  string
+	OutPackagePath           string
+	ImportPackagePaths       []string
+	Objects                  *[]string
+	ExcludeTables            []string
+	ExcludeMaterializedViews []string
+	StructNamePrefix         string
+	StructNameSuffix         string
+	TrimTablePrefixes        []string
+	ModelFileNamePattern     string
+	FieldWithTypeTag         *bool
+	FieldWithIndexTag        *bool
+	ModelsOnly               bool
+	CreateOutDir             *bool
+	MaxFields                int
+	QueryInterfaceTables     []string
+	FailFast                 bool
+	DetectForeignKeys        bool
+	// EnableSoftDelete unset keeps gen's convention of modeling a deleted_at
+	// timestamp as gorm.DeletedAt; true applies it to SoftDeleteColumn with an
+	// index tag, and false turns it off.
+	EnableSoftDelete *bool
+	SoftDeleteColumn string
+	// JSONTagStrategy defaults to camel; JSONTagOverridesByTable still wins.
+	JSONTagStrategy         JSONTagStrategy
 	JSONTagOverridesByTable map[string]map[string]string
-	ExtraFields             map[string][]ExtraField
-	TypeMap                 map[string]string
-	GeneratedTypes          GeneratedTypesConfig
-	DbInit                  GenerateDbInitConfig
-	NamingStrategy          schema.NamingStrategy `toml:"-"`
-	CleanUp                 bool
-	DbHost                  string
-	DbPort                  int
-	DbName                  string
-	DbUser                  string
-	DbPassword              string
-	DbSSLMode               bool
-	SQLiteDBPath            string
-	sourceFormat            configSourceFormat
+	// ColumnTypeOverridesByTable maps table and database column name to the Go
+	// type of that one field, ahead of TypeMap and the dialect defaults.
+	ColumnTypeOverridesByTable map[string]map[string]string
+	SensitiveColumns           map[string][]string
+	ExtraFields                map[string][]ExtraField
+	TypeMap                    map[string]string
+	GeneratedTypes             GeneratedTypesConfig
+	DbInit                     GenerateDbInitConfig
+	Helpers                    HelpersConfig
+	GenerateAuditTriggers      bool
+	GenerateMatviewRefresh     bool
+	MoneyType                  MoneyType
+	CatalogSource              CatalogSource
+	StrictNullability          bool
+	LargeObjectColumns         map[string][]string
+	NamingStrategy             schema.NamingStrategy `toml:"-"`
+	NamingStrategyByTable      map[string]schema.NamingStrategy
+	CleanUp                    bool
+	DbHost                     string
+	DbPort                     int
+	DbName                     string
+	DbUser                     string
+	DbPassword                 string
+	DbSSLMode                  bool
+	DbSSLModeString            string
+	SQLiteDBPath               string
+	sourceFormat               configSourceFormat
+
+	// EnvReferences holds the unexpanded value of each setting that contained
+	// ${VAR} references, keyed by setting name, so DbInit can read the
+	// variables at runtime instead of embedding their values.
+	EnvReferences map[string]string `toml:"-"`
+
+	// TransformModels is a programmatic escape hatch: it runs once per
+	// generation after every configured post-processing step (ExtraFields,
+	// JSONTagOverridesByTable, dialect fixes) and before the models are
+	// handed to gen's ApplyBasic, so edits to the fields land in the output.
+	TransformModels func(models []*GeneratedModel) `toml:"-"`
+}
+
+// GeneratedModel is the mutable view of one model passed to TransformModels.
+// Fields are the same values gen renders; edit, reorder, or drop them in place.
+type GeneratedModel struct {
+	TableName  string
+	StructName string
+	Fields     []gen.Field
 }
 
 type ExtraField struct {
@@ -61,6 +146,12 @@ type GeneratedTypesConfig struct {
 	RelativePath string
 	PackagePath  string
 	TypeMap      map[string]string
+	// CheckEnums maps "table.column" to the name of a string enum generated
+	// from the column's CHECK (column IN (...)) constraint.
+	CheckEnums map[string]string
+	// GenerateEnumTypes adds a TypeMap entry for every PostgreSQL enum, and
+	// enum array, used by the generated objects that no TypeMap maps yet.
+	GenerateEnumTypes bool
 }
 
 type GenerateDbInitConfig struct {
@@ -68,6 +159,28 @@ type GenerateDbInitConfig struct {
 	IncludeAutoMigrate              bool
 	GenerateAppSettingsRegistration bool
 	UseSlogGormLogger               bool
+	Namespace                       string
+	CreateDatabaseIfMissing         bool
+	GenerateDBStats                 bool
+	LogFormat                       string
+	LogLevel                        string
+}
+
+// HelpersConfig toggles optional helper files rendered next to the generated
+// models.
+type HelpersConfig struct {
+	GeneratePreloadConstants bool
+	GenerateSchemaDoc        bool
+	GenerateFindOrCreate     bool
+	GenerateSchemaAssertion  bool
+	GenerateScopes           bool
+	GenerateUpdateHelpers    bool
+	GenerateERD              bool
+	GenerateExists           bool
+	GenerateMapConversion    bool
+	GenerateTxHelpers        bool
+	TenantColumn             string
+	EnforceTenant            bool
 }
 
 var (
@@ -100,6 +213,9 @@ func (c *Config) Normalize() {
 	if c.JSONTagOverridesByTable == nil {
 		c.JSONTagOverridesByTable = map[string]map[string]string{}
 	}
+	if c.SensitiveColumns == nil {
+		c.SensitiveColumns = map[string][]string{}
+	}
 
 	if c.GeneratedTypes.HasEntries() {
 		if strings.TrimSpace(c.GeneratedTypes.RelativePath) == "" {
@@ -148,6 +264,79 @@ func (c Config) Validate() error {
 	if err := validateObjects(c.Objects); err != nil {
 		return err
 	}
+	if err := validateExcludePatterns("ExcludeTables", c.ExcludeTables); err != nil {
+		return err
+	}
+	if err := validateExcludePatterns("ExcludeMaterializedViews", c.ExcludeMaterializedViews); err != nil {
+		return err
+	}
+	if err := c.DbInit.Validate(); err != nil {
+		return err
+	}
+	if err := validateStructNameAffixes(c.StructNamePrefix, c.StructNameSuffix); err != nil {
+		return err
+	}
+	if strings.TrimSpace(c.SoftDeleteColumn) != "" && (c.EnableSoftDelete == nil || !*c.EnableSoftDelete) {
+		return fmt.Errorf("SoftDeleteColumn requires EnableSoftDelete = true")
+	}
+	switch c.JSONTagStrategy {
+	case "", JSONTagStrategyCamel, JSONTagStrategySnake, JSONTagStrategyPascal, JSONTagStrategyNone:
+	default:
+		return fmt.Errorf("JSONTagStrategy must be %q, %q, %q, or %q, got %q", JSONTagStrategyCamel, JSONTagStrategySnake, JSONTagStrategyPascal, JSONTagStrategyNone, c.JSONTagStrategy)
+	}
+	for _, table := range c.QueryInterfaceTables {
+		if strings.TrimSpace(table) == "" {
+			return fmt.Errorf("QueryInterfaceTables must not contain empty names")
+		}
+	}
+	if c.ModelsOnly && len(c.QueryInterfaceTables) > 0 {
+		return fmt.Errorf("QueryInterfaceTables has no effect with ModelsOnly, which writes no query files")
+	}
+	if c.ModelsOnly && c.Helpers.GenerateTxHelpers {
+		return fmt.Errorf("Helpers.GenerateTxHelpers has no effect with ModelsOnly, which writes no query files")
+	}
+	for table, columns := range c.ColumnTypeOverridesByTable {
+		if strings.TrimSpace(table) == "" {
+			return fmt.Errorf("ColumnTypeOverridesByTable contains an empty table name")
+		}
+		for column, goType := range columns {
+			if strings.TrimSpace(column) == "" {
+				return fmt.Errorf("ColumnTypeOverridesByTable[%q] contains an empty column name", table)
+			}
+			if strings.TrimSpace(goType) == "" {
+				return fmt.Errorf("ColumnTypeOverridesByTable[%q][%q] must not be empty", table, column)
+			}
+		}
+	}
+	for table := range c.NamingStrategyByTable {
+		if strings.TrimSpace(table) == "" {
+			return fmt.Errorf("NamingStrategyByTable contains an empty table name")
+		}
+	}
+	for _, prefix := range c.TrimTablePrefixes {
+		if strings.TrimSpace(prefix) == "" {
+			return fmt.Errorf("TrimTablePrefixes must not contain empty entries")
+		}
+	}
+	if c.MaxFields < 0 {
+		return fmt.Errorf("MaxFields must not be negative, got %d", c.MaxFields)
+	}
+	if _, err := c.ModelFileName("example_table", "ExampleTable"); err != nil {
+		return err
+	}
+	if c.Helpers.EnforceTenant && strings.TrimSpace(c.Helpers.TenantColumn) == "" {
+		return fmt.Errorf("Helpers.EnforceTenant requires Helpers.TenantColumn")
+	}
+	withTypeTag, withIndexTag := c.GenFieldTags()
+	if c.Helpers.GenerateSchemaAssertion && !withTypeTag {
+		return fmt.Errorf("Helpers.GenerateSchemaAssertion requires FieldWithTypeTag")
+	}
+	if c.Helpers.GenerateFindOrCreate && !withIndexTag {
+		return fmt.Errorf("Helpers.GenerateFindOrCreate requires FieldWithIndexTag")
+	}
+	if c.Helpers.GenerateExists && !withIndexTag {
+		return fmt.Errorf("Helpers.GenerateExists requires FieldWithIndexTag")
+	}
 
 	switch c.DatabaseDialect {
 	case PostgreSQL:
@@ -157,9 +346,22 @@ func (c Config) Validate() error {
 		if strings.TrimSpace(c.DbName) == "" {
 			return fmt.Errorf("DbName is required for postgresql dialect")
 		}
+		if mode := strings.TrimSpace(c.DbSSLModeString); mode != "" && !slices.Contains(postgresSSLModes, mode) {
+			return fmt.Errorf("DbSSLModeString must be one of %s, got %q", strings.Join(postgresSSLModes, ", "), c.DbSSLModeString)
+		}
 		if err := c.GeneratedTypes.Validate(); err != nil {
 			return err
 		}
+		switch c.MoneyType {
+		case "", MoneyTypeString, MoneyTypeInt64Cents, MoneyTypeDecimal:
+		default:
+			return fmt.Errorf("MoneyType must be %q, %q, or %q, got %q", MoneyTypeString, MoneyTypeInt64Cents, MoneyTypeDecimal, c.MoneyType)
+		}
+		switch c.CatalogSource {
+		case "", CatalogSourceInformationSchema, CatalogSourcePgCatalog:
+		default:
+			return fmt.Errorf("CatalogSource must be %q or %q, got %q", CatalogSourceInformationSchema, CatalogSourcePgCatalog, c.CatalogSource)
+		}
 	case SQLite:
 		if strings.TrimSpace(c.SQLiteDBPath) == "" {
 			return fmt.Errorf("SqliteDbPath is required for sqlite dialect")
@@ -167,6 +369,30 @@ func (c Config) Validate() error {
 		if c.GeneratedTypes.HasEntries() {
 			return fmt.Errorf("GeneratedTypes is currently only supported for postgresql dialect")
 		}
+		if c.GenerateAuditTriggers {
+			return fmt.Errorf("GenerateAuditTriggers is currently only supported for postgresql dialect")
+		}
+		if c.GenerateMatviewRefresh {
+			return fmt.Errorf("GenerateMatviewRefresh is currently only supported for postgresql dialect")
+		}
+		if c.MoneyType != "" {
+			return fmt.Errorf("MoneyType is currently only supported for postgresql dialect")
+		}
+		if c.CatalogSource != "" {
+			return fmt.Errorf("CatalogSource is currently only supported for postgresql dialect")
+		}
+		if c.StrictNullability {
+			return fmt.Errorf("StrictNullability is currently only supported for postgresql dialect")
+		}
+		if len(c.LargeObjectColumns) > 0 {
+			return fmt.Errorf("LargeObjectColumns is currently only supported for postgresql dialect")
+		}
+		if len(c.ExcludeMaterializedViews) > 0 {
+			return fmt.Errorf("ExcludeMaterializedViews is currently only supported for postgresql dialect")
+		}
+		if c.DbInit.CreateDatabaseIfMissing {
+			return fmt.Errorf("DbInit.CreateDatabaseIfMissing is currently only supported for postgresql dialect")
+		}
 	default:
 		return fmt.Errorf("DatabaseDialect must be %q or %q", PostgreSQL, SQLite)
 	}
@@ -174,8 +400,143 @@ func (c Config) Validate() error {
 	return nil
 }
 
+// GenFieldTags resolves gen's FieldWithTypeTag and FieldWithIndexTag flags.
+// Both default to on in every dialect, because AutoMigrate in DbInit and
+// several helpers read the tags; an explicit config value wins.
+func (c Config) GenFieldTags() (withTypeTag bool, withIndexTag bool) {
+	withTypeTag, withIndexTag = true, true
+	if c.FieldWithTypeTag != nil {
+		withTypeTag = *c.FieldWithTypeTag
+	}
+	if c.FieldWithIndexTag != nil {
+		withIndexTag = *c.FieldWithIndexTag
+	}
+	return withTypeTag, withIndexTag
+}
+
+// DefaultSoftDeleteColumn is the soft-delete column when SoftDeleteColumn is
+// not set.
+const DefaultSoftDeleteColumn = "deleted_at"
+
+// SoftDeleteColumnName returns the column generated as gorm.DeletedAt, or ""
+// when EnableSoftDelete is false.
+func (c Config) SoftDeleteColumnName() string {
+	if c.EnableSoftDelete != nil && !*c.EnableSoftDelete {
+		return ""
+	}
+	if column := strings.TrimSpace(c.SoftDeleteColumn); column != "" {
+		return column
+	}
+	return DefaultSoftDeleteColumn
+}
+
+// CreatesOutDir reports whether generation creates OutPath and its models
+// directory when they are missing. It defaults to true.
+func (c Config) CreatesOutDir() bool {
+	return c.CreateOutDir == nil || *c.CreateOutDir
+}
+
+// ModelStructName returns the Go struct name generated for a table or view.
+// The prefix and suffix wrap the naming strategy's result so initialisms such
+// as APIKey are preserved. A NamingStrategyByTable entry takes the place of
+// NamingStrategy for its table. The longest matching TrimTablePrefixes entry is
+// stripped first, unless it is the whole table name.
+func (c Config) ModelStructName(tableName string) string {
+	strategy := c.NamingStrategy
+	if override, ok := c.NamingStrategyByTable[tableName]; ok {
+		strategy = override
+	}
+	return strings.TrimSpace(c.StructNamePrefix) + strategy.SchemaName(c.trimTablePrefix(tableName)) + strings.TrimSpace(c.StructNameSuffix)
+}
+
+// ModelFileName renders ModelFileNamePattern for one model. It returns an
+// empty name when no pattern is configured. gen always appends ".gen.go", so a
+// trailing ".go" or ".gen.go" in the pattern is dropped.
+func (c Config) ModelFileName(tableName, structName string) (string, error) {
+	pattern := strings.TrimSpace(c.ModelFileNamePattern)
+	if pattern == "" {
+		return "", nil
+	}
+	tmpl, err := template.New("ModelFileNamePattern").Option("missingkey=error").Parse(pattern)
+	if err != nil {
+		return "", fmt.Errorf("ModelFileNamePattern is invalid: %w", err)
+	}
+	var b strings.Builder
+	if err := tmpl.Execute(&b, struct{ Table, Struct string }{Table: tableName, Struct: structName}); err != nil {
+		return "", fmt.Errorf("ModelFileNamePattern is invalid: %w", err)
+	}
+	name := strings.TrimSuffix(strings.TrimSuffix(b.String(), ".gen.go"), ".go")
+	if name == "" || strings.ContainsAny(name, `/\`) {
+		return "", fmt.Errorf("ModelFileNamePattern must render a plain file name, got %q", b.String())
+	}
+	return name, nil
+}
+
+func (c Config) trimTablePrefix(tableName string) string {
+	longest := ""
+	for _, prefix := range c.TrimTablePrefixes {
+		if len(prefix) > len(longest) && len(prefix) < len(tableName) && strings.HasPrefix(tableName, prefix) {
+			longest = prefix
+		}
+	}
+	return strings.TrimPrefix(tableName, longest)
+}
+
+func validateStructNameAffixes(prefix, suffix string) error {
+	prefix = strings.TrimSpace(prefix)
+	if prefix != "" && (!token.IsIdentifier(prefix) || !token.IsExported(prefix)) {
+		return fmt.Errorf("StructNamePrefix must be an exported Go identifier, got %q", prefix)
+	}
+	suffix = strings.TrimSpace(suffix)
+	if suffix != "" && !token.IsIdentifier("X"+suffix) {
+		return fmt.Errorf("StructNameSuffix must only contain Go identifier characters, got %q", suffix)
+	}
+	return nil
+}
+
+func (d GenerateDbInitConfig) Validate() error {
+	switch d.LogFormat {
+	case "", "text", "json":
+	default:
+		return fmt.Errorf("DbInit.LogFormat must be \"text\" or \"json\", got %q", d.LogFormat)
+	}
+	switch d.LogLevel {
+	case "", "debug", "info", "warn", "error":
+	default:
+		return fmt.Errorf("DbInit.LogLevel must be \"debug\", \"info\", \"warn\", or \"error\", got %q", d.LogLevel)
+	}
+
+	namespace := strings.TrimSpace(d.Namespace)
+	if namespace == "" {
+		return nil
+	}
+	if !token.IsIdentifier(namespace) || !token.IsExported(namespace) {
+		return fmt.Errorf("DbInit.Namespace must be an exported Go identifier, got %q", d.Namespace)
+	}
+	return nil
+}
+
+// postgresSSLModes are the sslmode values libpq accepts.
+var postgresSSLModes = []string{"disable", "allow", "prefer", "require", "verify-ca", "verify-full"}
+
+// PostgresSSLMode returns the libpq sslmode for the connection: DbSSLModeString
+// when set, otherwise "require" or "disable" from DbSSLMode.
+func (c Config) PostgresSSLMode() string {
+	if mode := strings.TrimSpace(c.DbSSLModeString); mode != "" {
+		return mode
+	}
+	if c.DbSSLMode {
+		return "require"
+	}
+	return "disable"
+}
+
+func (h HelpersConfig) Enabled() bool {
+	return h != HelpersConfig{}
+}
+
 func (g GeneratedTypesConfig) HasEntries() bool {
-	return len(g.TypeMap) > 0
+	return len(g.TypeMap) > 0 || len(g.CheckEnums) > 0 || g.GenerateEnumTypes
 }
 
 func (g GeneratedTypesConfig) Validate() error {
@@ -208,6 +569,15 @@ func (g GeneratedTypesConfig) Validate() error {
 			return fmt.Errorf("GeneratedTypes.TypeMap[%q] must not be empty", dbType)
 		}
 	}
+	for column, goType := range g.CheckEnums {
+		table, name, ok := strings.Cut(column, ".")
+		if !ok || strings.TrimSpace(table) == "" || strings.TrimSpace(name) == "" || strings.Contains(name, ".") {
+			return fmt.Errorf("GeneratedTypes.CheckEnums key %q must have the form \"table.column\"", column)
+		}
+		if strings.TrimSpace(goType) == "" {
+			return fmt.Errorf("GeneratedTypes.CheckEnums[%q] must not be empty", column)
+		}
+	}
 
 	return nil
 }
@@ -258,3 +628,17 @@ func validateObjects(objects *[]string) error {
 	}
 	return nil
 }
+
+// validateExcludePatterns rejects empty entries and malformed path.Match globs
+// up front, since a bad pattern would otherwise silently match nothing.
+func validateExcludePatterns(option string, patterns []string) error {
+	for _, pattern := range patterns {
+		if strings.TrimSpace(pattern) == "" {
+			return fmt.Errorf("%s contains an empty pattern", option)
+		}
+		if _, err := path.Match(pattern, ""); err != nil {
+			return fmt.Errorf("%s pattern %q: %w", option, pattern, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,60 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+)
+
+// EnvReferencePattern matches a ${VAR} reference and captures the variable
+// name.
+var EnvReferencePattern = regexp.MustCompile(`\$\{([A-Za-z_][A-Za-z0-9_]*)\}`)
+
+// expandEnv resolves ${VAR} references in the output path and connection
+// settings from the process environment. A bare $ is left alone so passwords
+// may contain it; a reference to an unset variable is an error rather than an
+// empty string. The unexpanded value of each setting that had a reference is
+// kept in EnvReferences.
+func (c *Config) expandEnv() error {
+	fields := []struct {
+		name  string
+		value *string
+	}{
+		{"OutPath", &c.OutPath},
+		{"DbHost", &c.DbHost},
+		{"DbName", &c.DbName},
+		{"DbUser", &c.DbUser},
+		{"DbPassword", &c.DbPassword},
+		{"SQLiteDBPath", &c.SQLiteDBPath},
+	}
+	for _, field := range fields {
+		expanded, err := expandEnvReferences(*field.value)
+		if err != nil {
+			return fmt.Errorf("%s: %w", field.name, err)
+		}
+		if EnvReferencePattern.MatchString(*field.value) {
+			if c.EnvReferences == nil {
+				c.EnvReferences = map[string]string{}
+			}
+			c.EnvReferences[field.name] = *field.value
+		}
+		*field.value = expanded
+	}
+	return nil
+}
+
+func expandEnvReferences(value string) (string, error) {
+	var missing string
+	expanded := EnvReferencePattern.ReplaceAllStringFunc(value, func(reference string) string {
+		name := EnvReferencePattern.FindStringSubmatch(reference)[1]
+		resolved, exists := os.LookupEnv(name)
+		if !exists && missing == "" {
+			missing = name
+		}
+		return resolved
+	})
+	if missing != "" {
+		return "", fmt.Errorf("environment variable %q is not set", missing)
+	}
+	return expanded, nil
+}
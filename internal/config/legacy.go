@@ -8,30 +8,39 @@ import (
 )
 
 type legacyFileConfig struct {
-	DatabaseDialect         DatabaseDialect
-	OutPath                 string
-	OutPackagePath          string
-	ImportPackagePaths      []string
-	Tables                  *[]string `toml:"Tables"`
-	MaterializedViews       *[]string `toml:"MaterializedViews"`
-	JSONTagOverridesByTable map[string]map[string]string
-	ExtraFields             map[string][]ExtraField
-	TypeMap                 map[string]string
-	DomainTypeMap           map[string]string `toml:"DomainTypeMap"`
-	GenerateDbInit          bool
-	IncludeAutoMigrate      bool
-	CleanUp                 bool
-	DbHost                  string
-	DbPort                  int
-	DbName                  string
-	DbUser                  string
-	DbPassword              string
-	DbSSLMode               bool
-	SQLiteDBPath            string `toml:"SqliteDbPath"`
-	LegacySQLiteDBPath      string `toml:"Sqlitedbpath"`
+	DatabaseDialect            DatabaseDialect
+	OutPath                    string
+	OutPackagePath             string
+	ImportPackagePaths         []string
+	Tables                     *[]string `toml:"Tables"`
+	Views                      *[]string `toml:"Views"`
+	MaterializedViews          *[]string `toml:"MaterializedViews"`
+	ExcludeTables              []string
+	ExcludeMaterializedViews   []string
+	EnableSoftDelete           *bool
+	SoftDeleteColumn           string
+	TrimTablePrefixes          []string
+	JSONTagStrategy            JSONTagStrategy
+	JSONTagOverridesByTable    map[string]map[string]string
+	ColumnTypeOverridesByTable map[string]map[string]string
+	ExtraFields                map[string][]ExtraField
+	TypeMap                    map[string]string
+	DomainTypeMap              map[string]string `toml:"DomainTypeMap"`
+	GenerateDbInit             bool
+	IncludeAutoMigrate         bool
+	CleanUp                    bool
+	DbHost                     string
+	DbPort                     int
+	DbName                     string
+	DbUser                     string
+	DbPassword                 string
+	DbSSLMode                  bool
+	DbSSLModeString            string
+	SQLiteDBPath               string `toml:"SqliteDbPath"`
+	LegacySQLiteDBPath         string `toml:"Sqlitedbpath"`
 }
 
-func loadLegacy(data []byte, path string) (Config, error) {
+func loadLegacy(data []byte, path string, expandEnv bool) (Config, error) {
 	var raw legacyFileConfig
 	if _, err := toml.Decode(string(data), &raw); err != nil {
 		return Config{}, fmt.Errorf("parse TOML config %s: %w", path, err)
@@ -48,31 +57,38 @@ func loadLegacy(data []byte, path string) (Config, error) {
 	}
 
 	cfg := Config{
-		DatabaseDialect:         raw.DatabaseDialect,
-		OutPath:                 raw.OutPath,
-		OutPackagePath:          raw.OutPackagePath,
-		ImportPackagePaths:      append([]string(nil), raw.ImportPackagePaths...),
-		Objects:                 mergeObjectLists(raw.Tables, raw.MaterializedViews),
-		JSONTagOverridesByTable: raw.JSONTagOverridesByTable,
-		ExtraFields:             raw.ExtraFields,
-		TypeMap:                 typeMap,
+		DatabaseDialect:            raw.DatabaseDialect,
+		OutPath:                    raw.OutPath,
+		OutPackagePath:             raw.OutPackagePath,
+		ImportPackagePaths:         append([]string(nil), raw.ImportPackagePaths...),
+		Objects:                    mergeObjectLists(raw.Tables, raw.Views, raw.MaterializedViews),
+		ExcludeTables:              append([]string(nil), raw.ExcludeTables...),
+		ExcludeMaterializedViews:   append([]string(nil), raw.ExcludeMaterializedViews...),
+		EnableSoftDelete:           raw.EnableSoftDelete,
+		SoftDeleteColumn:           raw.SoftDeleteColumn,
+		TrimTablePrefixes:          append([]string(nil), raw.TrimTablePrefixes...),
+		JSONTagStrategy:            raw.JSONTagStrategy,
+		JSONTagOverridesByTable:    raw.JSONTagOverridesByTable,
+		ColumnTypeOverridesByTable: raw.ColumnTypeOverridesByTable,
+		ExtraFields:                raw.ExtraFields,
+		TypeMap:                    typeMap,
 		DbInit: GenerateDbInitConfig{
 			Enabled:            raw.GenerateDbInit,
 			IncludeAutoMigrate: raw.IncludeAutoMigrate,
 		},
-		CleanUp:      raw.CleanUp,
-		DbHost:       raw.DbHost,
-		DbPort:       raw.DbPort,
-		DbName:       raw.DbName,
-		DbUser:       raw.DbUser,
-		DbPassword:   raw.DbPassword,
-		DbSSLMode:    raw.DbSSLMode,
-		SQLiteDBPath: sqlitePath,
-		sourceFormat: configSourceFormatLegacy,
+		CleanUp:         raw.CleanUp,
+		DbHost:          raw.DbHost,
+		DbPort:          raw.DbPort,
+		DbName:          raw.DbName,
+		DbUser:          raw.DbUser,
+		DbPassword:      raw.DbPassword,
+		DbSSLMode:       raw.DbSSLMode,
+		DbSSLModeString: raw.DbSSLModeString,
+		SQLiteDBPath:    sqlitePath,
+		sourceFormat:    configSourceFormatLegacy,
 	}
 
-	cfg.Normalize()
-	if err := cfg.Validate(); err != nil {
+	if err := finishLoad(&cfg, expandEnv); err != nil {
 		return Config{}, fmt.Errorf("validate config %s: %w", path, err)
 	}
 
@@ -9,27 +9,73 @@ import (
 
 const CurrentConfigVersion = 1
 
+// Load reads a config that describes exactly one database.
 func Load(path string) (Config, error) {
+	return loadOne(path, true)
+}
+
+// LoadUnexpanded is Load without ${VAR} interpolation, for commands that write
+// the config back out and must not bake environment values into it.
+func LoadUnexpanded(path string) (Config, error) {
+	return loadOne(path, false)
+}
+
+func loadOne(path string, expandEnv bool) (Config, error) {
+	cfgs, err := loadAll(path, expandEnv)
+	if err != nil {
+		return Config{}, err
+	}
+	if len(cfgs) != 1 {
+		return Config{}, fmt.Errorf("config %s defines %d databases; this command supports exactly one", path, len(cfgs))
+	}
+	return cfgs[0], nil
+}
+
+// LoadAll reads a config and returns one effective Config per database. A
+// versioned config with [[Databases]] entries yields one Config per entry.
+// ${VAR} references in OutPath, the SQLite path, and the PostgreSQL host, name,
+// user, and password are resolved from the environment before validation.
+func LoadAll(path string) ([]Config, error) {
+	return loadAll(path, true)
+}
+
+func loadAll(path string, expandEnv bool) ([]Config, error) {
 	data, err := os.ReadFile(path)
 	if err != nil {
-		return Config{}, fmt.Errorf("read config %s: %w", path, err)
+		return nil, fmt.Errorf("read config %s: %w", path, err)
 	}
 
 	configVersion, hasVersion, err := detectConfigVersion(data)
 	if err != nil {
-		return Config{}, fmt.Errorf("parse TOML config %s: %w", path, err)
+		return nil, fmt.Errorf("parse TOML config %s: %w", path, err)
 	}
 
 	if !hasVersion {
-		return loadLegacy(data, path)
+		cfg, err := loadLegacy(data, path, expandEnv)
+		if err != nil {
+			return nil, err
+		}
+		return []Config{cfg}, nil
 	}
 
 	switch configVersion {
 	case CurrentConfigVersion:
-		return loadVersioned(data, path)
+		return loadVersioned(data, path, expandEnv)
 	default:
-		return Config{}, fmt.Errorf("validate config %s: unsupported ConfigVersion %d", path, configVersion)
+		return nil, fmt.Errorf("validate config %s: unsupported ConfigVersion %d", path, configVersion)
+	}
+}
+
+// finishLoad resolves ${VAR} references when expandEnv is set, then applies
+// defaults and validates cfg.
+func finishLoad(cfg *Config, expandEnv bool) error {
+	if expandEnv {
+		if err := cfg.expandEnv(); err != nil {
+			return err
+		}
 	}
+	cfg.Normalize()
+	return cfg.Validate()
 }
 
 func detectConfigVersion(data []byte) (int, bool, error) {
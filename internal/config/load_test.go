@@ -42,6 +42,7 @@ DatabaseDialect = "postgresql"
 DbHost = "localhost"
 DbName = "example"
 Tables = ["tickets", "ticket_comments"]
+Views = ["ticket_search", "tickets"]
 MaterializedViews = ["ticket_rollup", "tickets"]
 
 [TypeMap]
@@ -59,7 +60,7 @@ MaterializedViews = ["ticket_rollup", "tickets"]
 	if cfg.Objects == nil {
 		t.Fatal("expected legacy object lists to merge into Objects")
 	}
-	wantObjects := []string{"tickets", "ticket_comments", "ticket_rollup"}
+	wantObjects := []string{"tickets", "ticket_comments", "ticket_search", "ticket_rollup"}
 	gotObjects := *cfg.Objects
 	if len(gotObjects) != len(wantObjects) {
 		t.Fatalf("expected %d objects, got %d: %#v", len(wantObjects), len(gotObjects), gotObjects)
@@ -196,6 +197,41 @@ Name = "example"
 	}
 }
 
+func TestLoadAcceptsGenerateEnumTypesWithoutTypeMap(t *testing.T) {
+	t.Parallel()
+
+	cfgPath := writeConfig(t, `
+ConfigVersion = 1
+
+[Generator]
+OutPath = "./generated"
+
+[Database]
+Dialect = "postgresql"
+
+[Database.PostgreSQL]
+Host = "localhost"
+Name = "example"
+
+[PostgreSQL.GeneratedTypes]
+GenerateEnumTypes = true
+`)
+
+	cfg, err := Load(cfgPath)
+	if err != nil {
+		t.Fatalf("load config: %v", err)
+	}
+	if !cfg.GeneratedTypes.GenerateEnumTypes || !cfg.GeneratedTypes.HasEntries() {
+		t.Fatalf("expected GenerateEnumTypes to enable generated types, got %+v", cfg.GeneratedTypes)
+	}
+	if cfg.GeneratedTypes.RelativePath != filepath.Join("models", "dbtypes") {
+		t.Fatalf("expected default generated relative path models/dbtypes, got %q", cfg.GeneratedTypes.RelativePath)
+	}
+	if !strings.Contains(RenderVersionedTOML(cfg), "GenerateEnumTypes = true") {
+		t.Fatalf("expected rendered config to keep GenerateEnumTypes:\n%s", RenderVersionedTOML(cfg))
+	}
+}
+
 func TestLoadRejectsUnsupportedConfigVersion(t *testing.T) {
 	t.Parallel()
 
@@ -280,6 +316,265 @@ Path = "./test.db"
 	}
 }
 
+func TestLoadValidatesExcludePatterns(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name    string
+		options string
+		dialect string
+		wantErr string
+	}{
+		{name: "valid", options: `ExcludeTables = ["schema_migrations", "*_audit"]`, dialect: "sqlite"},
+		{name: "malformed", options: `ExcludeTables = ["tmp_["]`, dialect: "sqlite", wantErr: `ExcludeTables pattern "tmp_["`},
+		{name: "empty", options: `ExcludeMaterializedViews = [""]`, dialect: "postgresql", wantErr: "ExcludeMaterializedViews contains an empty pattern"},
+		{name: "sqlite matviews", options: `ExcludeMaterializedViews = ["mv_*"]`, dialect: "sqlite", wantErr: "ExcludeMaterializedViews is currently only supported for postgresql"},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			cfgPath := writeConfig(t, `
+ConfigVersion = 1
+
+[Generator]
+OutPath = "./generated"
+`+tc.options+`
+
+[Database]
+Dialect = "`+tc.dialect+`"
+
+[Database.PostgreSQL]
+Host = "localhost"
+Name = "example"
+
+[Database.SQLite]
+Path = "./test.db"
+`)
+
+			cfg, err := Load(cfgPath)
+			if tc.wantErr == "" {
+				if err != nil {
+					t.Fatalf("load config: %v", err)
+				}
+				if len(cfg.ExcludeTables) != 2 || !strings.Contains(RenderVersionedTOML(cfg), `"*_audit",`) {
+					t.Fatalf("expected ExcludeTables to load and render, got %v", cfg.ExcludeTables)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tc.wantErr) {
+				t.Fatalf("expected error containing %q, got %v", tc.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestLoadAcceptsColumnTypeOverridesByTable(t *testing.T) {
+	t.Parallel()
+
+	cfgPath := writeConfig(t, `
+ConfigVersion = 1
+
+[Generator]
+OutPath = "./generated"
+ImportPackagePaths = ["github.com/shopspring/decimal"]
+
+[Database]
+Dialect = "postgresql"
+
+[Database.PostgreSQL]
+Host = "localhost"
+Name = "example"
+
+[ColumnTypeOverridesByTable."invoices"]
+subtotal = "decimal.Decimal"
+total = "decimal.Decimal"
+`)
+
+	cfg, err := Load(cfgPath)
+	if err != nil {
+		t.Fatalf("load config: %v", err)
+	}
+	if cfg.ColumnTypeOverridesByTable["invoices"]["total"] != "decimal.Decimal" {
+		t.Fatalf("expected column type overrides to load, got %v", cfg.ColumnTypeOverridesByTable)
+	}
+	if !strings.Contains(RenderVersionedTOML(cfg), "[ColumnTypeOverridesByTable.\"invoices\"]\n\"subtotal\" = \"decimal.Decimal\"") {
+		t.Fatalf("expected column type overrides to render:\n%s", RenderVersionedTOML(cfg))
+	}
+
+	cfg.ColumnTypeOverridesByTable["invoices"]["total"] = " "
+	if err := cfg.Validate(); err == nil || !strings.Contains(err.Error(), `ColumnTypeOverridesByTable["invoices"]["total"] must not be empty`) {
+		t.Fatalf("expected an empty override type to be rejected, got %v", err)
+	}
+}
+
+func TestLoadExpandsEnvironmentReferences(t *testing.T) {
+	t.Setenv("GORMDB2STRUCT_TEST_DB_HOST", "db.internal")
+	t.Setenv("GORMDB2STRUCT_TEST_DB_PASSWORD", "pa$$word")
+	t.Setenv("GORMDB2STRUCT_TEST_OUT", "billing")
+
+	cfgPath := writeConfig(t, `
+ConfigVersion = 1
+
+[Generator]
+OutPath = "./generated/${GORMDB2STRUCT_TEST_OUT}"
+
+[Database]
+Dialect = "postgresql"
+
+[Database.PostgreSQL]
+Host = "${GORMDB2STRUCT_TEST_DB_HOST}"
+Name = "example"
+User = "app$user"
+Password = "${GORMDB2STRUCT_TEST_DB_PASSWORD}"
+`)
+
+	cfg, err := Load(cfgPath)
+	if err != nil {
+		t.Fatalf("load config: %v", err)
+	}
+	if cfg.OutPath != "./generated/billing" || cfg.DbHost != "db.internal" || cfg.DbPassword != "pa$$word" {
+		t.Fatalf("expected environment references to be expanded, got OutPath=%q DbHost=%q DbPassword=%q", cfg.OutPath, cfg.DbHost, cfg.DbPassword)
+	}
+	if cfg.DbUser != "app$user" {
+		t.Fatalf("expected a bare $ to be left alone, got %q", cfg.DbUser)
+	}
+	if cfg.EnvReferences["DbPassword"] != "${GORMDB2STRUCT_TEST_DB_PASSWORD}" || cfg.EnvReferences["DbHost"] != "${GORMDB2STRUCT_TEST_DB_HOST}" {
+		t.Fatalf("expected the unexpanded references to be kept, got %v", cfg.EnvReferences)
+	}
+	if _, ok := cfg.EnvReferences["DbUser"]; ok {
+		t.Fatalf("expected settings without references to be left out of EnvReferences, got %v", cfg.EnvReferences)
+	}
+
+	raw, err := LoadUnexpanded(cfgPath)
+	if err != nil {
+		t.Fatalf("load unexpanded config: %v", err)
+	}
+	if raw.DbPassword != "${GORMDB2STRUCT_TEST_DB_PASSWORD}" {
+		t.Fatalf("expected LoadUnexpanded to keep the reference, got %q", raw.DbPassword)
+	}
+}
+
+func TestLoadRejectsUnsetEnvironmentReference(t *testing.T) {
+	t.Parallel()
+
+	cfgPath := writeConfig(t, `
+OutPath = "./generated"
+DatabaseDialect = "sqlite"
+Sqlitedbpath = "${GORMDB2STRUCT_TEST_UNSET_SQLITE_PATH}"
+`)
+
+	_, err := Load(cfgPath)
+	if err == nil || !strings.Contains(err.Error(), `SQLiteDBPath: environment variable "GORMDB2STRUCT_TEST_UNSET_SQLITE_PATH" is not set`) {
+		t.Fatalf("expected an unset environment variable to be rejected, got %v", err)
+	}
+}
+
+func TestLoadSoftDeleteSettings(t *testing.T) {
+	t.Parallel()
+
+	cfgPath := writeConfig(t, `
+ConfigVersion = 1
+
+[Generator]
+OutPath = "./generated"
+EnableSoftDelete = true
+SoftDeleteColumn = "removed_at"
+
+[Database]
+Dialect = "sqlite"
+
+[Database.SQLite]
+Path = "./example.db"
+`)
+
+	cfg, err := Load(cfgPath)
+	if err != nil {
+		t.Fatalf("load config: %v", err)
+	}
+	if got := cfg.SoftDeleteColumnName(); got != "removed_at" {
+		t.Fatalf("expected removed_at to be the soft-delete column, got %q", got)
+	}
+	rendered := RenderVersionedTOML(cfg)
+	if !strings.Contains(rendered, "EnableSoftDelete = true\n") || !strings.Contains(rendered, `SoftDeleteColumn = "removed_at"`) {
+		t.Fatalf("expected soft-delete settings to render, got:\n%s", rendered)
+	}
+
+	if got := (Config{}).SoftDeleteColumnName(); got != DefaultSoftDeleteColumn {
+		t.Fatalf("expected %s by default, got %q", DefaultSoftDeleteColumn, got)
+	}
+	disabled := false
+	if got := (Config{EnableSoftDelete: &disabled}).SoftDeleteColumnName(); got != "" {
+		t.Fatalf("expected EnableSoftDelete = false to turn soft deletes off, got %q", got)
+	}
+
+	cfg.EnableSoftDelete = nil
+	if err := cfg.Validate(); err == nil || !strings.Contains(err.Error(), "SoftDeleteColumn requires EnableSoftDelete = true") {
+		t.Fatalf("expected SoftDeleteColumn without EnableSoftDelete to be rejected, got %v", err)
+	}
+}
+
+func TestLoadRejectsUnknownJSONTagStrategy(t *testing.T) {
+	t.Parallel()
+
+	cfgPath := writeConfig(t, `
+OutPath = "./generated"
+DatabaseDialect = "sqlite"
+Sqlitedbpath = "./example.db"
+JsonTagStrategy = "kebab"
+`)
+
+	_, err := Load(cfgPath)
+	if err == nil || !strings.Contains(err.Error(), `JSONTagStrategy must be "camel", "snake", "pascal", or "none", got "kebab"`) {
+		t.Fatalf("expected an unknown JSONTagStrategy to be rejected, got %v", err)
+	}
+}
+
+func TestPostgresSSLMode(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		sslMode       bool
+		sslModeString string
+		want          string
+	}{
+		{want: "disable"},
+		{sslMode: true, want: "require"},
+		{sslMode: true, sslModeString: "verify-full", want: "verify-full"},
+		{sslModeString: "prefer", want: "prefer"},
+	}
+	for _, tc := range cases {
+		cfg := Config{DbSSLMode: tc.sslMode, DbSSLModeString: tc.sslModeString}
+		if got := cfg.PostgresSSLMode(); got != tc.want {
+			t.Fatalf("PostgresSSLMode(%t, %q) = %q, want %q", tc.sslMode, tc.sslModeString, got, tc.want)
+		}
+	}
+}
+
+func TestLoadRejectsUnknownSSLModeString(t *testing.T) {
+	t.Parallel()
+
+	cfgPath := writeConfig(t, `
+ConfigVersion = 1
+
+[Generator]
+OutPath = "./generated"
+
+[Database]
+Dialect = "postgresql"
+
+[Database.PostgreSQL]
+Host = "localhost"
+Name = "example"
+SSLModeString = "enable"
+`)
+
+	_, err := Load(cfgPath)
+	if err == nil || !strings.Contains(err.Error(), `DbSSLModeString must be one of disable, allow, prefer, require, verify-ca, verify-full, got "enable"`) {
+		t.Fatalf("expected an invalid sslmode to be rejected, got %v", err)
+	}
+}
+
 func TestLoadRejectsGeneratedTypesForSQLiteInVersionedConfig(t *testing.T) {
 	t.Parallel()
 
@@ -342,25 +637,741 @@ PackagePath = "example.com/generated/types"
 	}
 }
 
-func writeConfig(t *testing.T, content string) string {
-	t.Helper()
+func TestLoadRejectsUnexportedDbInitNamespace(t *testing.T) {
+	t.Parallel()
 
-	tmpDir := t.TempDir()
-	cfgPath := filepath.Join(tmpDir, "config.toml")
-	if err := os.WriteFile(cfgPath, []byte(content), 0o644); err != nil {
-		t.Fatalf("write config: %v", err)
+	cfgPath := writeConfig(t, `
+ConfigVersion = 1
+
+[Generator]
+OutPath = "./generated"
+
+[Database]
+Dialect = "sqlite"
+
+[Database.SQLite]
+Path = "./test.db"
+
+[DbInit]
+Enabled = true
+Namespace = "analytics"
+`)
+
+	_, err := Load(cfgPath)
+	if err == nil {
+		t.Fatal("expected unexported DbInit.Namespace to be rejected")
+	}
+	if !strings.Contains(err.Error(), "DbInit.Namespace must be an exported Go identifier") {
+		t.Fatalf("expected namespace validation error, got %v", err)
 	}
-	return cfgPath
 }
 
-func assertContainsImportPath(t *testing.T, importPaths []string, want string) {
-	t.Helper()
+func TestLoadRejectsUnknownDbInitLogFormat(t *testing.T) {
+	t.Parallel()
 
-	for _, importPath := range importPaths {
-		if importPath == want {
-			return
-		}
+	cfgPath := writeConfig(t, `
+ConfigVersion = 1
+
+[Generator]
+OutPath = "./generated"
+
+[Database]
+Dialect = "sqlite"
+
+[Database.SQLite]
+Path = "./test.db"
+
+[DbInit]
+Enabled = true
+LogFormat = "logfmt"
+`)
+
+	_, err := Load(cfgPath)
+	if err == nil || !strings.Contains(err.Error(), `DbInit.LogFormat must be "text" or "json"`) {
+		t.Fatalf("expected LogFormat validation error, got %v", err)
 	}
+}
 
-	t.Fatalf("expected import path %q to be present in %#v", want, importPaths)
+func TestLoadRejectsCreateDatabaseIfMissingForSQLite(t *testing.T) {
+	t.Parallel()
+
+	cfgPath := writeConfig(t, `
+ConfigVersion = 1
+
+[Generator]
+OutPath = "./generated"
+
+[Database]
+Dialect = "sqlite"
+
+[Database.SQLite]
+Path = "./test.db"
+
+[DbInit]
+Enabled = true
+CreateDatabaseIfMissing = true
+`)
+
+	_, err := Load(cfgPath)
+	if err == nil || !strings.Contains(err.Error(), "CreateDatabaseIfMissing is currently only supported for postgresql dialect") {
+		t.Fatalf("expected CreateDatabaseIfMissing to be rejected for sqlite, got %v", err)
+	}
+}
+
+func TestLoadRejectsEnforceTenantWithoutTenantColumn(t *testing.T) {
+	t.Parallel()
+
+	cfgPath := writeConfig(t, `
+ConfigVersion = 1
+
+[Generator]
+OutPath = "./generated"
+
+[Database]
+Dialect = "sqlite"
+
+[Database.SQLite]
+Path = "./test.db"
+
+[Helpers]
+EnforceTenant = true
+`)
+
+	_, err := Load(cfgPath)
+	if err == nil || !strings.Contains(err.Error(), "EnforceTenant requires Helpers.TenantColumn") {
+		t.Fatalf("expected EnforceTenant without TenantColumn to be rejected, got %v", err)
+	}
+}
+
+func TestLoadValidatesCatalogSource(t *testing.T) {
+	t.Parallel()
+
+	cfgPath := writeConfig(t, `
+ConfigVersion = 1
+
+[Generator]
+OutPath = "./generated"
+
+[Database]
+Dialect = "postgresql"
+
+[Database.PostgreSQL]
+Host = "localhost"
+Port = 5432
+Name = "app"
+
+[PostgreSQL]
+CatalogSource = "pg_catalog"
+`)
+	cfg, err := Load(cfgPath)
+	if err != nil {
+		t.Fatalf("load config: %v", err)
+	}
+	if cfg.CatalogSource != CatalogSourcePgCatalog {
+		t.Fatalf("expected CatalogSource %q, got %q", CatalogSourcePgCatalog, cfg.CatalogSource)
+	}
+
+	cfgPath = writeConfig(t, `
+ConfigVersion = 1
+
+[Generator]
+OutPath = "./generated"
+
+[Database]
+Dialect = "postgresql"
+
+[Database.PostgreSQL]
+Host = "localhost"
+Port = 5432
+Name = "app"
+
+[PostgreSQL]
+CatalogSource = "pg_class"
+`)
+	if _, err := Load(cfgPath); err == nil || !strings.Contains(err.Error(), "CatalogSource must be") {
+		t.Fatalf("expected an unknown CatalogSource to be rejected, got %v", err)
+	}
+}
+
+func TestLoadStrictNullability(t *testing.T) {
+	t.Parallel()
+
+	cfgPath := writeConfig(t, `
+ConfigVersion = 1
+
+[Generator]
+OutPath = "./generated"
+
+[Database]
+Dialect = "postgresql"
+
+[Database.PostgreSQL]
+Host = "localhost"
+Port = 5432
+Name = "app"
+
+[PostgreSQL]
+StrictNullability = true
+`)
+	cfg, err := Load(cfgPath)
+	if err != nil {
+		t.Fatalf("load config: %v", err)
+	}
+	if !cfg.StrictNullability {
+		t.Fatal("expected StrictNullability to be loaded from [PostgreSQL]")
+	}
+	if rendered := RenderVersionedTOML(cfg); !strings.Contains(rendered, "StrictNullability = true") {
+		t.Fatalf("expected the rendered config to keep StrictNullability, got:\n%s", rendered)
+	}
+
+	cfgPath = writeConfig(t, `
+ConfigVersion = 1
+
+[Generator]
+OutPath = "./generated"
+
+[Database]
+Dialect = "sqlite"
+
+[Database.SQLite]
+Path = "./example.db"
+
+[PostgreSQL]
+StrictNullability = true
+`)
+	if _, err := Load(cfgPath); err == nil || !strings.Contains(err.Error(), "StrictNullability is currently only supported for postgresql dialect") {
+		t.Fatalf("expected StrictNullability to be rejected for SQLite, got %v", err)
+	}
+}
+
+func TestLoadRejectsAuditTriggersForSQLite(t *testing.T) {
+	t.Parallel()
+
+	cfgPath := writeConfig(t, `
+ConfigVersion = 1
+
+[Generator]
+OutPath = "./generated"
+
+[Database]
+Dialect = "sqlite"
+
+[Database.SQLite]
+Path = "./test.db"
+
+[PostgreSQL]
+GenerateAuditTriggers = true
+`)
+
+	_, err := Load(cfgPath)
+	if err == nil {
+		t.Fatal("expected GenerateAuditTriggers to be rejected for sqlite")
+	}
+	if !strings.Contains(err.Error(), "GenerateAuditTriggers is currently only supported for postgresql dialect") {
+		t.Fatalf("expected audit trigger dialect error, got %v", err)
+	}
+}
+
+func TestLoadGenerateMatviewRefresh(t *testing.T) {
+	t.Parallel()
+
+	cfgPath := writeConfig(t, `
+ConfigVersion = 1
+
+[Generator]
+OutPath = "./generated"
+
+[Database]
+Dialect = "postgresql"
+
+[Database.PostgreSQL]
+Host = "localhost"
+Port = 5432
+Name = "app"
+
+[PostgreSQL]
+GenerateMatviewRefresh = true
+`)
+	cfg, err := Load(cfgPath)
+	if err != nil {
+		t.Fatalf("load config: %v", err)
+	}
+	if !cfg.GenerateMatviewRefresh {
+		t.Fatal("expected GenerateMatviewRefresh to load")
+	}
+	if rendered := RenderVersionedTOML(cfg); !strings.Contains(rendered, "GenerateMatviewRefresh = true") {
+		t.Fatalf("expected GenerateMatviewRefresh to render, got:\n%s", rendered)
+	}
+
+	cfgPath = writeConfig(t, `
+ConfigVersion = 1
+
+[Generator]
+OutPath = "./generated"
+
+[Database]
+Dialect = "sqlite"
+
+[Database.SQLite]
+Path = "./test.db"
+
+[PostgreSQL]
+GenerateMatviewRefresh = true
+`)
+	if _, err := Load(cfgPath); err == nil || !strings.Contains(err.Error(), "GenerateMatviewRefresh is currently only supported for postgresql dialect") {
+		t.Fatalf("expected sqlite GenerateMatviewRefresh to be rejected, got %v", err)
+	}
+}
+
+func TestLoadAppliesStructNameAffixesAfterNamingStrategy(t *testing.T) {
+	t.Parallel()
+
+	cfgPath := writeConfig(t, `
+ConfigVersion = 1
+
+[Generator]
+OutPath = "./generated"
+StructNamePrefix = "Billing"
+StructNameSuffix = "Row"
+
+[Database]
+Dialect = "sqlite"
+
+[Database.SQLite]
+Path = "./test.db"
+`)
+
+	cfg, err := Load(cfgPath)
+	if err != nil {
+		t.Fatalf("load config: %v", err)
+	}
+	if got := cfg.ModelStructName("api_keys"); got != "BillingAPIKeyRow" {
+		t.Fatalf("expected BillingAPIKeyRow, got %q", got)
+	}
+
+	cfg.StructNamePrefix = "billing"
+	if err := cfg.Validate(); err == nil || !strings.Contains(err.Error(), "StructNamePrefix must be an exported Go identifier") {
+		t.Fatalf("expected unexported prefix to be rejected, got %v", err)
+	}
+}
+
+func TestLoadTrimTablePrefixesFromStructNames(t *testing.T) {
+	t.Parallel()
+
+	cfgPath := writeConfig(t, `
+ConfigVersion = 1
+
+[Generator]
+OutPath = "./generated"
+TrimTablePrefixes = ["tbl_", "mv_", "mv_sales_"]
+
+[Database]
+Dialect = "sqlite"
+
+[Database.SQLite]
+Path = "./test.db"
+
+[NamingStrategyByTable."tbl_media"]
+SingularTable = true
+`)
+
+	cfg, err := Load(cfgPath)
+	if err != nil {
+		t.Fatalf("load config: %v", err)
+	}
+	for table, want := range map[string]string{
+		"tbl_customer":     "Customer",
+		"mv_sales_summary": "Summary",
+		"mv_orders":        "Order",
+		"tbl_":             "Tbl",
+		"orders":           "Order",
+		"tbl_media":        "Media",
+	} {
+		if got := cfg.ModelStructName(table); got != want {
+			t.Fatalf("ModelStructName(%q) = %q, want %q", table, got, want)
+		}
+	}
+	if rendered := RenderVersionedTOML(cfg); !strings.Contains(rendered, "TrimTablePrefixes = [\n  \"tbl_\",\n") {
+		t.Fatalf("expected TrimTablePrefixes to render, got:\n%s", rendered)
+	}
+
+	cfg.TrimTablePrefixes = []string{"tbl_", " "}
+	if err := cfg.Validate(); err == nil || !strings.Contains(err.Error(), "TrimTablePrefixes must not contain empty entries") {
+		t.Fatalf("expected an empty prefix to be rejected, got %v", err)
+	}
+}
+
+func TestLoadNamingStrategyByTableOverridesStructNames(t *testing.T) {
+	t.Parallel()
+
+	cfgPath := writeConfig(t, `
+ConfigVersion = 1
+
+[Generator]
+OutPath = "./generated"
+StructNamePrefix = "Legacy"
+
+[Database]
+Dialect = "sqlite"
+
+[Database.SQLite]
+Path = "./test.db"
+
+[NamingStrategyByTable."Media"]
+SingularTable = true
+
+[NamingStrategyByTable."tbl_order_items"]
+TablePrefix = "tbl_"
+`)
+
+	cfg, err := Load(cfgPath)
+	if err != nil {
+		t.Fatalf("load config: %v", err)
+	}
+	if got := cfg.ModelStructName("Media"); got != "LegacyMedia" {
+		t.Fatalf("expected LegacyMedia, got %q", got)
+	}
+	if got := cfg.ModelStructName("tbl_order_items"); got != "LegacyOrderItem" {
+		t.Fatalf("expected LegacyOrderItem, got %q", got)
+	}
+	if got := cfg.ModelStructName("Data"); got != "LegacyDatum" {
+		t.Fatalf("expected tables without an override to keep the global strategy, got %q", got)
+	}
+
+	rendered := RenderVersionedTOML(cfg)
+	if !strings.Contains(rendered, "[NamingStrategyByTable.\"Media\"]\nSingularTable = true") ||
+		!strings.Contains(rendered, "[NamingStrategyByTable.\"tbl_order_items\"]\nTablePrefix = \"tbl_\"\nSingularTable = false") {
+		t.Fatalf("expected NamingStrategyByTable to render, got:\n%s", rendered)
+	}
+}
+
+func TestLoadAllInheritsTopLevelSettingsPerDatabase(t *testing.T) {
+	t.Parallel()
+
+	cfgPath := writeConfig(t, `
+ConfigVersion = 1
+
+[Generator]
+CleanUp = true
+
+[Database]
+Dialect = "sqlite"
+
+[DbInit]
+Enabled = true
+
+[TypeMap]
+"money" = "int64"
+
+[[Databases]]
+[Databases.Generator]
+OutPath = "./generated/app"
+[Databases.Database.SQLite]
+Path = "./app.db"
+
+[[Databases]]
+[Databases.Generator]
+OutPath = "./generated/analytics"
+[Databases.Database.SQLite]
+Path = "./analytics.db"
+[Databases.DbInit]
+Namespace = "Analytics"
+[Databases.TypeMap]
+"decimal" = "float64"
+`)
+
+	cfgs, err := LoadAll(cfgPath)
+	if err != nil {
+		t.Fatalf("load config: %v", err)
+	}
+	if len(cfgs) != 2 {
+		t.Fatalf("expected 2 configs, got %d", len(cfgs))
+	}
+
+	app, analytics := cfgs[0], cfgs[1]
+	if app.OutPath != "./generated/app" || app.SQLiteDBPath != "./app.db" || app.DbInit.Namespace != "" {
+		t.Fatalf("unexpected app config: %+v", app)
+	}
+	if analytics.OutPath != "./generated/analytics" || analytics.DbInit.Namespace != "Analytics" {
+		t.Fatalf("unexpected analytics config: %+v", analytics)
+	}
+	for _, cfg := range cfgs {
+		if !cfg.CleanUp || !cfg.DbInit.Enabled || cfg.TypeMap["money"] != "int64" {
+			t.Fatalf("expected top-level settings to be inherited, got %+v", cfg)
+		}
+	}
+	if _, exists := app.TypeMap["decimal"]; exists {
+		t.Fatal("expected per-database TypeMap entries not to leak into other databases")
+	}
+	if analytics.TypeMap["decimal"] != "float64" {
+		t.Fatalf("expected analytics TypeMap override, got %v", analytics.TypeMap)
+	}
+
+	if _, err := Load(cfgPath); err == nil || !strings.Contains(err.Error(), "defines 2 databases") {
+		t.Fatalf("expected Load to reject multi-database config, got %v", err)
+	}
+}
+
+func TestLoadAllRejectsDuplicateOutPathAndUnknownDatabaseKeys(t *testing.T) {
+	t.Parallel()
+
+	duplicatePath := writeConfig(t, `
+ConfigVersion = 1
+
+[Generator]
+OutPath = "./generated"
+
+[Database]
+Dialect = "sqlite"
+
+[[Databases]]
+[Databases.Database.SQLite]
+Path = "./app.db"
+
+[[Databases]]
+[Databases.Database.SQLite]
+Path = "./analytics.db"
+`)
+	if _, err := LoadAll(duplicatePath); err == nil || !strings.Contains(err.Error(), "Databases[0] and Databases[1] both write to OutPath") {
+		t.Fatalf("expected duplicate OutPath to be rejected, got %v", err)
+	}
+
+	unknownPath := writeConfig(t, `
+ConfigVersion = 1
+
+[Database]
+Dialect = "sqlite"
+
+[[Databases]]
+Bogus = true
+[Databases.Generator]
+OutPath = "./generated"
+[Databases.Database.SQLite]
+Path = "./app.db"
+`)
+	if _, err := LoadAll(unknownPath); err == nil || !strings.Contains(err.Error(), "Databases.Bogus") {
+		t.Fatalf("expected unknown Databases key to be rejected, got %v", err)
+	}
+}
+
+func TestModelFileNameRendersPattern(t *testing.T) {
+	t.Parallel()
+
+	cfg := Config{ModelFileNamePattern: "{{.Table}}.model.go"}
+	name, err := cfg.ModelFileName("tickets", "Ticket")
+	if err != nil {
+		t.Fatalf("render file name: %v", err)
+	}
+	if name != "tickets.model" {
+		t.Fatalf("expected tickets.model, got %q", name)
+	}
+
+	cfg.ModelFileNamePattern = "{{.Schema}}/{{.Table}}"
+	if _, err := cfg.ModelFileName("tickets", "Ticket"); err == nil || !strings.Contains(err.Error(), "ModelFileNamePattern is invalid") {
+		t.Fatalf("expected unknown template field to be rejected, got %v", err)
+	}
+}
+
+func TestLoadResolvesGenFieldTagsPerDialectWithOverrides(t *testing.T) {
+	t.Parallel()
+
+	cfgPath := writeConfig(t, `
+ConfigVersion = 1
+
+[Generator]
+OutPath = "./generated"
+FieldWithIndexTag = false
+
+[Database]
+Dialect = "sqlite"
+
+[Database.SQLite]
+Path = "./test.db"
+`)
+
+	cfg, err := Load(cfgPath)
+	if err != nil {
+		t.Fatalf("load config: %v", err)
+	}
+	if withTypeTag, withIndexTag := cfg.GenFieldTags(); !withTypeTag || withIndexTag {
+		t.Fatalf("expected default type tags and index tag override, got type=%t index=%t", withTypeTag, withIndexTag)
+	}
+	if !strings.Contains(RenderVersionedTOML(cfg), "FieldWithIndexTag = false\n") {
+		t.Fatal("expected rendered config to keep the FieldWithIndexTag override")
+	}
+	for _, dialect := range []DatabaseDialect{PostgreSQL, SQLite} {
+		if withTypeTag, withIndexTag := (Config{DatabaseDialect: dialect}).GenFieldTags(); !withTypeTag || !withIndexTag {
+			t.Fatalf("expected %s to default to type and index tags, got type=%t index=%t", dialect, withTypeTag, withIndexTag)
+		}
+	}
+
+	cfgPath = writeConfig(t, `
+ConfigVersion = 1
+
+[Generator]
+OutPath = "./generated"
+FieldWithIndexTag = false
+
+[Database]
+Dialect = "sqlite"
+
+[Database.SQLite]
+Path = "./test.db"
+
+[Helpers]
+GenerateFindOrCreate = true
+`)
+	if _, err := Load(cfgPath); err == nil || !strings.Contains(err.Error(), "GenerateFindOrCreate requires FieldWithIndexTag") {
+		t.Fatalf("expected FindOrCreate without index tags to be rejected, got %v", err)
+	}
+
+	cfgPath = writeConfig(t, `
+ConfigVersion = 1
+
+[Generator]
+OutPath = "./generated"
+FieldWithIndexTag = false
+
+[Database]
+Dialect = "sqlite"
+
+[Database.SQLite]
+Path = "./test.db"
+
+[Helpers]
+GenerateExists = true
+`)
+	if _, err := Load(cfgPath); err == nil || !strings.Contains(err.Error(), "GenerateExists requires FieldWithIndexTag") {
+		t.Fatalf("expected Exists without index tags to be rejected, got %v", err)
+	}
+}
+
+func writeConfig(t *testing.T, content string) string {
+	t.Helper()
+
+	tmpDir := t.TempDir()
+	cfgPath := filepath.Join(tmpDir, "config.toml")
+	if err := os.WriteFile(cfgPath, []byte(content), 0o644); err != nil {
+		t.Fatalf("write config: %v", err)
+	}
+	return cfgPath
+}
+
+func assertContainsImportPath(t *testing.T, importPaths []string, want string) {
+	t.Helper()
+
+	for _, importPath := range importPaths {
+		if importPath == want {
+			return
+		}
+	}
+
+	t.Fatalf("expected import path %q to be present in %#v", want, importPaths)
+}
+
+func TestLoadLargeObjectColumns(t *testing.T) {
+	t.Parallel()
+
+	cfgPath := writeConfig(t, `
+ConfigVersion = 1
+
+[Generator]
+OutPath = "./generated"
+
+[Database]
+Dialect = "postgresql"
+
+[Database.PostgreSQL]
+Host = "localhost"
+Port = 5432
+Name = "app"
+
+[PostgreSQL.LargeObjectColumns]
+"documents" = ["content_oid"]
+`)
+	cfg, err := Load(cfgPath)
+	if err != nil {
+		t.Fatalf("load config: %v", err)
+	}
+	if got := cfg.LargeObjectColumns["documents"]; len(got) != 1 || got[0] != "content_oid" {
+		t.Fatalf("expected LargeObjectColumns to load, got %v", cfg.LargeObjectColumns)
+	}
+	if rendered := RenderVersionedTOML(cfg); !strings.Contains(rendered, "[PostgreSQL.LargeObjectColumns]\n\"documents\" = [\"content_oid\"]") {
+		t.Fatalf("expected LargeObjectColumns to render, got:\n%s", rendered)
+	}
+
+	cfgPath = writeConfig(t, `
+ConfigVersion = 1
+
+[Generator]
+OutPath = "./generated"
+
+[Database]
+Dialect = "sqlite"
+
+[Database.SQLite]
+Path = "./test.db"
+
+[PostgreSQL.LargeObjectColumns]
+"documents" = ["content_oid"]
+`)
+	if _, err := Load(cfgPath); err == nil || !strings.Contains(err.Error(), "LargeObjectColumns is currently only supported") {
+		t.Fatalf("expected sqlite LargeObjectColumns to be rejected, got %v", err)
+	}
+}
+
+func TestLoadGeneratedTypesCheckEnums(t *testing.T) {
+	t.Parallel()
+
+	cfgPath := writeConfig(t, `
+ConfigVersion = 1
+
+[Generator]
+OutPath = "./generated"
+
+[Database]
+Dialect = "postgresql"
+
+[Database.PostgreSQL]
+Host = "localhost"
+Port = 5432
+Name = "app"
+
+[PostgreSQL.GeneratedTypes.CheckEnums]
+"tickets.priority" = "TicketPriority"
+`)
+	cfg, err := Load(cfgPath)
+	if err != nil {
+		t.Fatalf("load config: %v", err)
+	}
+	if cfg.GeneratedTypes.CheckEnums["tickets.priority"] != "TicketPriority" {
+		t.Fatalf("expected CheckEnums to load, got %v", cfg.GeneratedTypes.CheckEnums)
+	}
+	if cfg.GeneratedTypes.PackageName != "dbtypes" {
+		t.Fatalf("expected default generated types package, got %q", cfg.GeneratedTypes.PackageName)
+	}
+	if rendered := RenderVersionedTOML(cfg); !strings.Contains(rendered, "[PostgreSQL.GeneratedTypes.CheckEnums]\n\"tickets.priority\" = \"TicketPriority\"") {
+		t.Fatalf("expected CheckEnums to render, got:\n%s", rendered)
+	}
+
+	cfgPath = writeConfig(t, `
+ConfigVersion = 1
+
+[Generator]
+OutPath = "./generated"
+
+[Database]
+Dialect = "postgresql"
+
+[Database.PostgreSQL]
+Host = "localhost"
+Port = 5432
+Name = "app"
+
+[PostgreSQL.GeneratedTypes.CheckEnums]
+"priority" = "TicketPriority"
+`)
+	if _, err := Load(cfgPath); err == nil || !strings.Contains(err.Error(), `must have the form "table.column"`) {
+		t.Fatalf("expected malformed CheckEnums key to be rejected, got %v", err)
+	}
 }
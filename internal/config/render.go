@@ -4,6 +4,8 @@ import (
 	"fmt"
 	"sort"
 	"strings"
+
+	"gorm.io/gorm/schema"
 )
 
 const implicitImportPackagePath = "gorm.io/datatypes"
@@ -31,6 +33,57 @@ func RenderVersionedTOML(cfg Config) string {
 	if cfg.Objects != nil {
 		writeStringArray(&b, "Objects", append([]string(nil), (*cfg.Objects)...))
 	}
+	if len(cfg.ExcludeTables) > 0 {
+		writeStringArray(&b, "ExcludeTables", cfg.ExcludeTables)
+	}
+	if len(cfg.ExcludeMaterializedViews) > 0 {
+		writeStringArray(&b, "ExcludeMaterializedViews", cfg.ExcludeMaterializedViews)
+	}
+	if strings.TrimSpace(cfg.StructNamePrefix) != "" {
+		writeLine(&b, fmt.Sprintf("StructNamePrefix = %q", cfg.StructNamePrefix))
+	}
+	if strings.TrimSpace(cfg.StructNameSuffix) != "" {
+		writeLine(&b, fmt.Sprintf("StructNameSuffix = %q", cfg.StructNameSuffix))
+	}
+	if len(cfg.TrimTablePrefixes) > 0 {
+		writeStringArray(&b, "TrimTablePrefixes", append([]string(nil), cfg.TrimTablePrefixes...))
+	}
+	if strings.TrimSpace(cfg.ModelFileNamePattern) != "" {
+		writeLine(&b, fmt.Sprintf("ModelFileNamePattern = %q", cfg.ModelFileNamePattern))
+	}
+	if cfg.FieldWithTypeTag != nil {
+		writeLine(&b, fmt.Sprintf("FieldWithTypeTag = %t", *cfg.FieldWithTypeTag))
+	}
+	if cfg.FieldWithIndexTag != nil {
+		writeLine(&b, fmt.Sprintf("FieldWithIndexTag = %t", *cfg.FieldWithIndexTag))
+	}
+	if cfg.ModelsOnly {
+		writeLine(&b, "ModelsOnly = true")
+	}
+	if cfg.CreateOutDir != nil {
+		writeLine(&b, fmt.Sprintf("CreateOutDir = %t", *cfg.CreateOutDir))
+	}
+	if len(cfg.QueryInterfaceTables) > 0 {
+		writeStringArray(&b, "QueryInterfaceTables", append([]string(nil), cfg.QueryInterfaceTables...))
+	}
+	if cfg.MaxFields > 0 {
+		writeLine(&b, fmt.Sprintf("MaxFields = %d", cfg.MaxFields))
+	}
+	if cfg.FailFast {
+		writeLine(&b, "FailFast = true")
+	}
+	if cfg.DetectForeignKeys {
+		writeLine(&b, "DetectForeignKeys = true")
+	}
+	if cfg.EnableSoftDelete != nil {
+		writeLine(&b, fmt.Sprintf("EnableSoftDelete = %t", *cfg.EnableSoftDelete))
+	}
+	if strings.TrimSpace(cfg.SoftDeleteColumn) != "" {
+		writeLine(&b, fmt.Sprintf("SoftDeleteColumn = %q", cfg.SoftDeleteColumn))
+	}
+	if cfg.JSONTagStrategy != "" {
+		writeLine(&b, fmt.Sprintf("JSONTagStrategy = %q", cfg.JSONTagStrategy))
+	}
 	writeBlankLine(&b)
 
 	writeLine(&b, "# ----------------------------------------------------------------------")
@@ -49,6 +102,9 @@ func RenderVersionedTOML(cfg Config) string {
 		writeLine(&b, fmt.Sprintf("User = %q", cfg.DbUser))
 		writeLine(&b, fmt.Sprintf("Password = %q", cfg.DbPassword))
 		writeLine(&b, fmt.Sprintf("SSLMode = %t", cfg.DbSSLMode))
+		if strings.TrimSpace(cfg.DbSSLModeString) != "" {
+			writeLine(&b, fmt.Sprintf("SSLModeString = %q", cfg.DbSSLModeString))
+		}
 	case SQLite:
 		writeLine(&b, "[Database.SQLite]")
 		writeLine(&b, fmt.Sprintf("Path = %q", cfg.SQLiteDBPath))
@@ -63,6 +119,40 @@ func RenderVersionedTOML(cfg Config) string {
 	writeLine(&b, fmt.Sprintf("IncludeAutoMigrate = %t", cfg.DbInit.IncludeAutoMigrate))
 	writeLine(&b, fmt.Sprintf("GenerateAppSettingsRegistration = %t", cfg.DbInit.GenerateAppSettingsRegistration))
 	writeLine(&b, fmt.Sprintf("UseSlogGormLogger = %t", cfg.DbInit.UseSlogGormLogger))
+	if cfg.DbInit.CreateDatabaseIfMissing {
+		writeLine(&b, "CreateDatabaseIfMissing = true")
+	}
+	if cfg.DbInit.GenerateDBStats {
+		writeLine(&b, "GenerateDBStats = true")
+	}
+	if cfg.DbInit.LogFormat != "" {
+		writeLine(&b, fmt.Sprintf("LogFormat = %q", cfg.DbInit.LogFormat))
+	}
+	if cfg.DbInit.LogLevel != "" {
+		writeLine(&b, fmt.Sprintf("LogLevel = %q", cfg.DbInit.LogLevel))
+	}
+	if strings.TrimSpace(cfg.DbInit.Namespace) != "" {
+		writeLine(&b, fmt.Sprintf("Namespace = %q", cfg.DbInit.Namespace))
+	}
+
+	if cfg.Helpers.Enabled() {
+		writeBlankLine(&b)
+		writeLine(&b, "[Helpers]")
+		writeLine(&b, fmt.Sprintf("GeneratePreloadConstants = %t", cfg.Helpers.GeneratePreloadConstants))
+		writeLine(&b, fmt.Sprintf("GenerateSchemaDoc = %t", cfg.Helpers.GenerateSchemaDoc))
+		writeLine(&b, fmt.Sprintf("GenerateFindOrCreate = %t", cfg.Helpers.GenerateFindOrCreate))
+		writeLine(&b, fmt.Sprintf("GenerateSchemaAssertion = %t", cfg.Helpers.GenerateSchemaAssertion))
+		writeLine(&b, fmt.Sprintf("GenerateScopes = %t", cfg.Helpers.GenerateScopes))
+		writeLine(&b, fmt.Sprintf("GenerateUpdateHelpers = %t", cfg.Helpers.GenerateUpdateHelpers))
+		writeLine(&b, fmt.Sprintf("GenerateERD = %t", cfg.Helpers.GenerateERD))
+		writeLine(&b, fmt.Sprintf("GenerateExists = %t", cfg.Helpers.GenerateExists))
+		writeLine(&b, fmt.Sprintf("GenerateMapConversion = %t", cfg.Helpers.GenerateMapConversion))
+		writeLine(&b, fmt.Sprintf("GenerateTxHelpers = %t", cfg.Helpers.GenerateTxHelpers))
+		if strings.TrimSpace(cfg.Helpers.TenantColumn) != "" {
+			writeLine(&b, fmt.Sprintf("TenantColumn = %q", cfg.Helpers.TenantColumn))
+			writeLine(&b, fmt.Sprintf("EnforceTenant = %t", cfg.Helpers.EnforceTenant))
+		}
+	}
 
 	if filteredTypeMap := renderedTypeMap(cfg.TypeMap, versionedDefaultTypeMap); len(filteredTypeMap) > 0 {
 		writeBlankLine(&b)
@@ -78,22 +168,75 @@ func RenderVersionedTOML(cfg Config) string {
 
 	if len(cfg.JSONTagOverridesByTable) > 0 {
 		writeBlankLine(&b)
-		writeJSONTagOverrides(&b, cfg.JSONTagOverridesByTable)
+		writeTableStringMaps(&b, "JSONTagOverridesByTable", cfg.JSONTagOverridesByTable)
+	}
+
+	if len(cfg.ColumnTypeOverridesByTable) > 0 {
+		writeBlankLine(&b)
+		writeTableStringMaps(&b, "ColumnTypeOverridesByTable", cfg.ColumnTypeOverridesByTable)
 	}
 
-	if cfg.DatabaseDialect == PostgreSQL && cfg.GeneratedTypes.HasEntries() {
+	if len(cfg.NamingStrategyByTable) > 0 {
+		writeBlankLine(&b)
+		writeNamingStrategyByTable(&b, cfg.NamingStrategyByTable)
+	}
+
+	if len(cfg.SensitiveColumns) > 0 {
+		writeBlankLine(&b)
+		writeLine(&b, "[SensitiveColumns]")
+		writeStringArrayMap(&b, cfg.SensitiveColumns)
+	}
+
+	writePostgreSQLOptions := cfg.GenerateAuditTriggers || cfg.GenerateMatviewRefresh || cfg.MoneyType != "" || cfg.CatalogSource != "" || cfg.StrictNullability
+	if cfg.DatabaseDialect == PostgreSQL && (writePostgreSQLOptions || len(cfg.LargeObjectColumns) > 0 || cfg.GeneratedTypes.HasEntries()) {
 		writeBlankLine(&b)
 		writeBlankLine(&b)
 		writeLine(&b, "# ----------------------------------------------------------------------")
 		writeLine(&b, "# PostgreSQL-only sections")
 		writeLine(&b, "# ----------------------------------------------------------------------")
-		writeLine(&b, "[PostgreSQL.GeneratedTypes]")
-		writeLine(&b, fmt.Sprintf("PackageName = %q", cfg.GeneratedTypes.PackageName))
-		writeLine(&b, fmt.Sprintf("RelativePath = %q", cfg.GeneratedTypes.RelativePath))
-		writeLine(&b, fmt.Sprintf("PackagePath = %q", cfg.GeneratedTypes.PackagePath))
-		writeBlankLine(&b)
-		writeLine(&b, "[PostgreSQL.GeneratedTypes.TypeMap]")
-		writeStringMap(&b, cfg.GeneratedTypes.TypeMap)
+		if writePostgreSQLOptions {
+			writeLine(&b, "[PostgreSQL]")
+			writeLine(&b, fmt.Sprintf("GenerateAuditTriggers = %t", cfg.GenerateAuditTriggers))
+			if cfg.GenerateMatviewRefresh {
+				writeLine(&b, fmt.Sprintf("GenerateMatviewRefresh = %t", cfg.GenerateMatviewRefresh))
+			}
+			if cfg.MoneyType != "" {
+				writeLine(&b, fmt.Sprintf("MoneyType = %q", cfg.MoneyType))
+			}
+			if cfg.CatalogSource != "" {
+				writeLine(&b, fmt.Sprintf("CatalogSource = %q", cfg.CatalogSource))
+			}
+			if cfg.StrictNullability {
+				writeLine(&b, fmt.Sprintf("StrictNullability = %t", cfg.StrictNullability))
+			}
+		}
+		if len(cfg.LargeObjectColumns) > 0 {
+			if writePostgreSQLOptions {
+				writeBlankLine(&b)
+			}
+			writeLine(&b, "[PostgreSQL.LargeObjectColumns]")
+			writeStringArrayMap(&b, cfg.LargeObjectColumns)
+		}
+		if cfg.GeneratedTypes.HasEntries() {
+			if writePostgreSQLOptions || len(cfg.LargeObjectColumns) > 0 {
+				writeBlankLine(&b)
+			}
+			writeLine(&b, "[PostgreSQL.GeneratedTypes]")
+			writeLine(&b, fmt.Sprintf("PackageName = %q", cfg.GeneratedTypes.PackageName))
+			writeLine(&b, fmt.Sprintf("RelativePath = %q", cfg.GeneratedTypes.RelativePath))
+			writeLine(&b, fmt.Sprintf("PackagePath = %q", cfg.GeneratedTypes.PackagePath))
+			if cfg.GeneratedTypes.GenerateEnumTypes {
+				writeLine(&b, "GenerateEnumTypes = true")
+			}
+			writeBlankLine(&b)
+			writeLine(&b, "[PostgreSQL.GeneratedTypes.TypeMap]")
+			writeStringMap(&b, cfg.GeneratedTypes.TypeMap)
+			if len(cfg.GeneratedTypes.CheckEnums) > 0 {
+				writeBlankLine(&b)
+				writeLine(&b, "[PostgreSQL.GeneratedTypes.CheckEnums]")
+				writeStringMap(&b, cfg.GeneratedTypes.CheckEnums)
+			}
+		}
 	}
 
 	return b.String()
@@ -163,6 +306,21 @@ func writeStringMap(b *strings.Builder, values map[string]string) {
 	}
 }
 
+func writeStringArrayMap(b *strings.Builder, values map[string][]string) {
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		quoted := make([]string, 0, len(values[key]))
+		for _, value := range values[key] {
+			quoted = append(quoted, fmt.Sprintf("%q", value))
+		}
+		writeLine(b, fmt.Sprintf("%q = [%s]", key, strings.Join(quoted, ", ")))
+	}
+}
+
 func writeExtraFields(b *strings.Builder, values map[string][]ExtraField) {
 	tables := make([]string, 0, len(values))
 	for table := range values {
@@ -184,8 +342,8 @@ func writeExtraFields(b *strings.Builder, values map[string][]ExtraField) {
 	}
 }
 
-func writeJSONTagOverrides(b *strings.Builder, values map[string]map[string]string) {
-	writeLine(b, "[JSONTagOverridesByTable]")
+func writeTableStringMaps(b *strings.Builder, section string, values map[string]map[string]string) {
+	writeLine(b, "["+section+"]")
 
 	tables := make([]string, 0, len(values))
 	for table := range values {
@@ -195,7 +353,30 @@ func writeJSONTagOverrides(b *strings.Builder, values map[string]map[string]stri
 
 	for _, table := range tables {
 		writeBlankLine(b)
-		writeLine(b, fmt.Sprintf("[JSONTagOverridesByTable.%q]", table))
+		writeLine(b, fmt.Sprintf("[%s.%q]", section, table))
 		writeStringMap(b, values[table])
 	}
 }
+
+func writeNamingStrategyByTable(b *strings.Builder, values map[string]schema.NamingStrategy) {
+	writeLine(b, "[NamingStrategyByTable]")
+
+	tables := make([]string, 0, len(values))
+	for table := range values {
+		tables = append(tables, table)
+	}
+	sort.Strings(tables)
+
+	for _, table := range tables {
+		strategy := values[table]
+		writeBlankLine(b)
+		writeLine(b, fmt.Sprintf("[NamingStrategyByTable.%q]", table))
+		if strategy.TablePrefix != "" {
+			writeLine(b, fmt.Sprintf("TablePrefix = %q", strategy.TablePrefix))
+		}
+		writeLine(b, fmt.Sprintf("SingularTable = %t", strategy.SingularTable))
+		if strategy.NoLowerCase {
+			writeLine(b, "NoLowerCase = true")
+		}
+	}
+}
@@ -13,10 +13,27 @@ ConfigVersion = 1
 OutPath = "./generated"
 OutPackagePath = ""
 CleanUp = true
+CreateOutDir = true # create OutPath and OutPath/models when missing
 ImportPackagePaths = [
   "github.com/dan-sherwin/gormdb2struct/pgtypes",
 ]
 # Objects = ["tickets", "ticket_rollup"] # omit to generate all supported objects
+# ExcludeTables = ["schema_migrations", "*_audit", "tmp_*"] # path.Match globs over tables and views; wins over Objects
+# ExcludeMaterializedViews = ["mv_tmp_*"] # PostgreSQL: the same for materialized views
+# StructNamePrefix = "Billing" # wraps every struct name: BillingTicket, ...
+# StructNameSuffix = ""
+# TrimTablePrefixes = ["tbl_", "mv_"] # tbl_customer -> Customer; TableName() stays "tbl_customer"
+# ModelFileNamePattern = "{{.Table}}.model" # file base name; gen appends .gen.go -> tickets.model.gen.go
+# ModelsOnly = false # only model structs: no query package, no DbInit, CleanUp limited to models/
+# FieldWithTypeTag = true # gorm:"type:..." tags (default true)
+# FieldWithIndexTag = true # gorm:"index/uniqueIndex" tags (default true)
+# QueryInterfaceTables = ["accounts"] # only these get I<Model>Do query interfaces; omit for interfaces on every table
+# MaxFields = 0 # warn about structs with more fields than this; 0 disables the check
+# FailFast = false # fail generation instead of warning when a struct exceeds MaxFields
+# DetectForeignKeys = false # add belongs-to and has-many relation fields for every foreign key; ExtraFields win
+# EnableSoftDelete = true # SoftDeleteColumn becomes gorm.DeletedAt with an index tag; false keeps it a timestamp; omit for gen's deleted_at default
+# SoftDeleteColumn = "deleted_at" # requires EnableSoftDelete = true
+# JSONTagStrategy = "camel" # json tag names: "camel" (default), "snake", "pascal", or "none" for the column name as is
 
 
 
@@ -32,8 +49,9 @@ Host = "localhost"
 Port = 5432
 Name = "my_database"
 User = "my_user"
-Password = "secret"
-SSLMode = false
+Password = "secret" # or "${PGPASSWORD}": ${VAR} is read from the environment
+SSLMode = false # true connects with sslmode=require, false with sslmode=disable
+# SSLModeString = "verify-full" # any libpq sslmode, passed through verbatim; wins over SSLMode
 
 [Database.SQLite]
 Path = "./schema.db"
@@ -48,6 +66,26 @@ Enabled = true
 IncludeAutoMigrate = false
 GenerateAppSettingsRegistration = false
 UseSlogGormLogger = false
+# Namespace = "Analytics" # prefixes generated globals: AnalyticsDbInit, AnalyticsDB, ...
+# LogFormat = "json" # "text" or "json": DbInit installs a slog default handler before connecting
+# LogLevel = "info" # "debug", "info", "warn", or "error"
+# CreateDatabaseIfMissing = true # PostgreSQL: DbInit creates DbName via the "postgres" database when absent
+# GenerateDBStats = true # db_stats.go: DBStats() and DBHealth(ctx) report the connection pool of DB
+
+# Helpers: optional helper files generated next to the models.
+[Helpers]
+GeneratePreloadConstants = false # <Model>Rels.<Relation> compile-checked Preload paths
+GenerateSchemaDoc = false # SCHEMA.md with columns, keys, indexes, and relations per table/view
+GenerateFindOrCreate = false # FindOrCreate<Model>(db, where, defaults) for tables with a unique index
+GenerateSchemaAssertion = false # AssertSchema(db) checks the live columns and types at startup
+GenerateScopes = false # <Model>Active, <Model>NotDeleted, <Model>ByID(id) GORM scopes
+GenerateUpdateHelpers = false # Update<Model>(db, id, <Model>Updates{...}) typed partial updates
+GenerateERD = false # schema_gen.dot Graphviz diagram with foreign key edges
+GenerateExists = false # <Model>ExistsByID and <Model>ExistsBy<UniqueFields> via SELECT 1 ... LIMIT 1
+GenerateMapConversion = false # (m <Model>) ToMap() and <Model>FromMap(map[string]any) keyed by column name
+GenerateTxHelpers = false # <model>.WithTx(tx) binds a query object to a transaction
+# TenantColumn = "tenant_id" # ScopeByTenant(tenantID) for the tables that have this column
+# EnforceTenant = false # RegisterTenantEnforcement(db): callbacks that require WithTenant(ctx, id) on those tables
 
 # TypeMap: shared database type overrides (optional).
 # PostgreSQL: standard types, enums, domains, arrays, or existing custom packages.
@@ -72,21 +110,54 @@ UseSlogGormLogger = false
 # [JSONTagOverridesByTable."ticket_extended"]
 # subject_fts = "-"
 
+# ColumnTypeOverridesByTable: Go types for single columns, ahead of TypeMap (optional).
+# Keys are database column names; add the type's package to ImportPackagePaths.
+[ColumnTypeOverridesByTable]
+# [ColumnTypeOverridesByTable."invoices"]
+# total = "decimal.Decimal"
+
+# NamingStrategyByTable: struct naming for tables that break the schema's convention (optional).
+# The entry replaces the default strategy for that table; StructNamePrefix/Suffix still apply.
+[NamingStrategyByTable]
+# [NamingStrategyByTable."Media"]
+# SingularTable = true # Media stays Media instead of becoming Medium
+
+# SensitiveColumns: columns that must never reach JSON or logs (optional).
+# They get json:"-" (even over JSONTagOverridesByTable) and a redacting String().
+[SensitiveColumns]
+# "users" = ["password_hash", "api_secret"]
+
 
 
 # ----------------------------------------------------------------------
 # PostgreSQL-only sections
 # ----------------------------------------------------------------------
+[PostgreSQL]
+GenerateAuditTriggers = false # writes audit_gen.sql with row-change triggers for the generated tables
+GenerateMatviewRefresh = false # writes Refresh<Model>(db, concurrently) helpers for generated materialized views
+# MoneyType = "int64cents" # money columns: "string", "int64cents" (pgtypes.Money), or "decimal" (pgtypes.MoneyDecimal)
+# CatalogSource = "pg_catalog" # introspect via pg_catalog when information_schema is revoked (default "information_schema")
+# StrictNullability = true # pointers exactly for nullable columns; NOT NULL columns with a default become value types
+
+# LargeObjectColumns maps oid columns to pgtypes.LargeObject for streaming through the large object API.
+[PostgreSQL.LargeObjectColumns]
+# "documents" = ["content_oid"]
+
 # PostgreSQL.GeneratedTypes asks gormdb2struct to create wrapper types for you.
 [PostgreSQL.GeneratedTypes]
 PackageName = "dbtypes"
 RelativePath = "models/dbtypes"
 PackagePath = ""
+# GenerateEnumTypes = true # every enum used by a generated object gets a type: order_status -> OrderStatus
 
 [PostgreSQL.GeneratedTypes.TypeMap]
 # "ticket_status" = "TicketStatus"
 # "ticket_type" = "TicketType"
 # "ticket_type[]" = "TicketTypeArray"
 # "my_text_domain" = "MyTextDomain"
+
+# CheckEnums generates a string enum from a column's CHECK (column IN (...)) constraint.
+[PostgreSQL.GeneratedTypes.CheckEnums]
+# "tickets.priority" = "TicketPriority"
 `
 }
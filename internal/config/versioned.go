@@ -2,29 +2,53 @@ package config
 
 import (
 	"fmt"
+	"path/filepath"
 	"sort"
 	"strings"
 
 	"github.com/BurntSushi/toml"
+	"gorm.io/gorm/schema"
 )
 
 type versionedFileConfig struct {
-	ConfigVersion           int
-	Generator               versionedGeneratorConfig
-	Database                versionedDatabaseConfig
-	DbInit                  GenerateDbInitConfig
-	TypeMap                 map[string]string
-	ExtraFields             map[string][]ExtraField
-	JSONTagOverridesByTable map[string]map[string]string
-	PostgreSQL              versionedPostgreSQLConfig
+	ConfigVersion              int
+	Generator                  versionedGeneratorConfig
+	Database                   versionedDatabaseConfig
+	DbInit                     GenerateDbInitConfig
+	Helpers                    HelpersConfig
+	TypeMap                    map[string]string
+	ExtraFields                map[string][]ExtraField
+	JSONTagOverridesByTable    map[string]map[string]string
+	ColumnTypeOverridesByTable map[string]map[string]string
+	SensitiveColumns           map[string][]string
+	NamingStrategyByTable      map[string]schema.NamingStrategy
+	PostgreSQL                 versionedPostgreSQLConfig
+	Databases                  []toml.Primitive
 }
 
 type versionedGeneratorConfig struct {
-	OutPath            string
-	OutPackagePath     string
-	CleanUp            bool
-	ImportPackagePaths []string
-	Objects            *[]string
+	OutPath                  string
+	OutPackagePath           string
+	CleanUp                  bool
+	ImportPackagePaths       []string
+	Objects                  *[]string
+	ExcludeTables            []string
+	ExcludeMaterializedViews []string
+	StructNamePrefix         string
+	StructNameSuffix         string
+	TrimTablePrefixes        []string
+	ModelFileNamePattern     string
+	FieldWithTypeTag         *bool
+	FieldWithIndexTag        *bool
+	ModelsOnly               bool
+	CreateOutDir             *bool
+	MaxFields                int
+	QueryInterfaceTables     []string
+	FailFast                 bool
+	DetectForeignKeys        bool
+	EnableSoftDelete         *bool
+	SoftDeleteColumn         string
+	JSONTagStrategy          JSONTagStrategy
 }
 
 type versionedDatabaseConfig struct {
@@ -34,12 +58,13 @@ type versionedDatabaseConfig struct {
 }
 
 type versionedPostgreSQLConnectionConfig struct {
-	Host     string
-	Port     int
-	Name     string
-	User     string
-	Password string
-	SSLMode  bool
+	Host          string
+	Port          int
+	Name          string
+	User          string
+	Password      string
+	SSLMode       bool
+	SSLModeString string
 }
 
 type versionedSQLiteConnectionConfig struct {
@@ -47,48 +72,127 @@ type versionedSQLiteConnectionConfig struct {
 }
 
 type versionedPostgreSQLConfig struct {
-	GeneratedTypes GeneratedTypesConfig
+	GenerateAuditTriggers  bool
+	GenerateMatviewRefresh bool
+	MoneyType              MoneyType
+	CatalogSource          CatalogSource
+	StrictNullability      bool
+	LargeObjectColumns     map[string][]string
+	GeneratedTypes         GeneratedTypesConfig
 }
 
-func loadVersioned(data []byte, path string) (Config, error) {
+func loadVersioned(data []byte, path string, expandEnv bool) ([]Config, error) {
 	var raw versionedFileConfig
 	meta, err := toml.Decode(string(data), &raw)
 	if err != nil {
-		return Config{}, fmt.Errorf("parse TOML config %s: %w", path, err)
+		return nil, fmt.Errorf("parse TOML config %s: %w", path, err)
+	}
+
+	entries := []versionedFileConfig{raw}
+	if len(raw.Databases) > 0 {
+		entries, err = decodeVersionedDatabases(data, meta, raw.Databases)
+		if err != nil {
+			return nil, fmt.Errorf("parse TOML config %s: %w", path, err)
+		}
 	}
 
 	if undecoded := formatUndecodedKeys(meta.Undecoded()); len(undecoded) > 0 {
-		return Config{}, fmt.Errorf("validate config %s: unsupported keys for ConfigVersion = %d: %s", path, raw.ConfigVersion, strings.Join(undecoded, ", "))
+		return nil, fmt.Errorf("validate config %s: unsupported keys for ConfigVersion = %d: %s", path, raw.ConfigVersion, strings.Join(undecoded, ", "))
 	}
 
-	cfg := Config{
-		DatabaseDialect:         raw.Database.Dialect,
-		OutPath:                 raw.Generator.OutPath,
-		OutPackagePath:          raw.Generator.OutPackagePath,
-		ImportPackagePaths:      append([]string(nil), raw.Generator.ImportPackagePaths...),
-		Objects:                 raw.Generator.Objects,
-		JSONTagOverridesByTable: raw.JSONTagOverridesByTable,
-		ExtraFields:             raw.ExtraFields,
-		TypeMap:                 raw.TypeMap,
-		GeneratedTypes:          raw.PostgreSQL.GeneratedTypes,
-		DbInit:                  raw.DbInit,
-		CleanUp:                 raw.Generator.CleanUp,
-		DbHost:                  raw.Database.PostgreSQL.Host,
-		DbPort:                  raw.Database.PostgreSQL.Port,
-		DbName:                  raw.Database.PostgreSQL.Name,
-		DbUser:                  raw.Database.PostgreSQL.User,
-		DbPassword:              raw.Database.PostgreSQL.Password,
-		DbSSLMode:               raw.Database.PostgreSQL.SSLMode,
-		SQLiteDBPath:            raw.Database.SQLite.Path,
-		sourceFormat:            configSourceFormatVersioned,
+	cfgs := make([]Config, 0, len(entries))
+	outPaths := make(map[string]int, len(entries))
+	for i, entry := range entries {
+		cfg := entry.config()
+		if err := finishLoad(&cfg, expandEnv); err != nil {
+			if len(raw.Databases) > 0 {
+				return nil, fmt.Errorf("validate config %s: Databases[%d]: %w", path, i, err)
+			}
+			return nil, fmt.Errorf("validate config %s: %w", path, err)
+		}
+		outPath := filepath.Clean(cfg.OutPath)
+		if previous, exists := outPaths[outPath]; exists {
+			return nil, fmt.Errorf("validate config %s: Databases[%d] and Databases[%d] both write to OutPath %q", path, previous, i, cfg.OutPath)
+		}
+		outPaths[outPath] = i
+		cfgs = append(cfgs, cfg)
 	}
 
-	cfg.Normalize()
-	if err := cfg.Validate(); err != nil {
-		return Config{}, fmt.Errorf("validate config %s: %w", path, err)
+	return cfgs, nil
+}
+
+// decodeVersionedDatabases decodes each [[Databases]] entry on top of a fresh
+// copy of the top-level sections, so entries inherit shared settings such as
+// TypeMap and only override the keys they set.
+func decodeVersionedDatabases(data []byte, meta toml.MetaData, databases []toml.Primitive) ([]versionedFileConfig, error) {
+	entries := make([]versionedFileConfig, 0, len(databases))
+	for i, database := range databases {
+		var entry versionedFileConfig
+		if _, err := toml.Decode(string(data), &entry); err != nil {
+			return nil, err
+		}
+		entry.Databases = nil
+		if err := meta.PrimitiveDecode(database, &entry); err != nil {
+			return nil, fmt.Errorf("Databases[%d]: %w", i, err)
+		}
+		if len(entry.Databases) > 0 {
+			return nil, fmt.Errorf("Databases[%d]: nested Databases are not supported", i)
+		}
+		entries = append(entries, entry)
 	}
+	return entries, nil
+}
 
-	return cfg, nil
+func (raw versionedFileConfig) config() Config {
+	return Config{
+		DatabaseDialect:            raw.Database.Dialect,
+		OutPath:                    raw.Generator.OutPath,
+		OutPackagePath:             raw.Generator.OutPackagePath,
+		ImportPackagePaths:         append([]string(nil), raw.Generator.ImportPackagePaths...),
+		Objects:                    raw.Generator.Objects,
+		ExcludeTables:              append([]string(nil), raw.Generator.ExcludeTables...),
+		ExcludeMaterializedViews:   append([]string(nil), raw.Generator.ExcludeMaterializedViews...),
+		StructNamePrefix:           raw.Generator.StructNamePrefix,
+		StructNameSuffix:           raw.Generator.StructNameSuffix,
+		TrimTablePrefixes:          append([]string(nil), raw.Generator.TrimTablePrefixes...),
+		ModelFileNamePattern:       raw.Generator.ModelFileNamePattern,
+		FieldWithTypeTag:           raw.Generator.FieldWithTypeTag,
+		FieldWithIndexTag:          raw.Generator.FieldWithIndexTag,
+		ModelsOnly:                 raw.Generator.ModelsOnly,
+		CreateOutDir:               raw.Generator.CreateOutDir,
+		MaxFields:                  raw.Generator.MaxFields,
+		QueryInterfaceTables:       append([]string(nil), raw.Generator.QueryInterfaceTables...),
+		FailFast:                   raw.Generator.FailFast,
+		DetectForeignKeys:          raw.Generator.DetectForeignKeys,
+		EnableSoftDelete:           raw.Generator.EnableSoftDelete,
+		SoftDeleteColumn:           raw.Generator.SoftDeleteColumn,
+		JSONTagStrategy:            raw.Generator.JSONTagStrategy,
+		JSONTagOverridesByTable:    raw.JSONTagOverridesByTable,
+		ColumnTypeOverridesByTable: raw.ColumnTypeOverridesByTable,
+		SensitiveColumns:           raw.SensitiveColumns,
+		NamingStrategyByTable:      raw.NamingStrategyByTable,
+		ExtraFields:                raw.ExtraFields,
+		TypeMap:                    raw.TypeMap,
+		GeneratedTypes:             raw.PostgreSQL.GeneratedTypes,
+		DbInit:                     raw.DbInit,
+		Helpers:                    raw.Helpers,
+		GenerateAuditTriggers:      raw.PostgreSQL.GenerateAuditTriggers,
+		GenerateMatviewRefresh:     raw.PostgreSQL.GenerateMatviewRefresh,
+		MoneyType:                  raw.PostgreSQL.MoneyType,
+		CatalogSource:              raw.PostgreSQL.CatalogSource,
+		StrictNullability:          raw.PostgreSQL.StrictNullability,
+		LargeObjectColumns:         raw.PostgreSQL.LargeObjectColumns,
+		CleanUp:                    raw.Generator.CleanUp,
+		DbHost:                     raw.Database.PostgreSQL.Host,
+		DbPort:                     raw.Database.PostgreSQL.Port,
+		DbName:                     raw.Database.PostgreSQL.Name,
+		DbUser:                     raw.Database.PostgreSQL.User,
+		DbPassword:                 raw.Database.PostgreSQL.Password,
+		DbSSLMode:                  raw.Database.PostgreSQL.SSLMode,
+		DbSSLModeString:            raw.Database.PostgreSQL.SSLModeString,
+		SQLiteDBPath:               raw.Database.SQLite.Path,
+		sourceFormat:               configSourceFormatVersioned,
+	}
 }
 
 func formatUndecodedKeys(keys []toml.Key) []string {
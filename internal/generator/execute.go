@@ -0,0 +1,116 @@
+package generator
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"golang.org/x/tools/imports"
+	"gorm.io/gen"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// genErrorLogger records what gen logs through the gorm logger right before it
+// panics, which is the only place the cause of a failed step ends up.
+type genErrorLogger struct {
+	logger.Interface
+	errors []string
+}
+
+func (l *genErrorLogger) LogMode(level logger.LogLevel) logger.Interface {
+	l.Interface = l.Interface.LogMode(level)
+	return l
+}
+
+func (l *genErrorLogger) Error(_ context.Context, msg string, data ...any) {
+	l.errors = append(l.errors, fmt.Sprintf(msg, data...))
+}
+
+// runGenStep calls a gen step such as Execute or ApplyBasic, which return
+// nothing and panic on failure, and turns that panic into an error carrying
+// the cause gen logged.
+func runGenStep(g *gen.Generator, db *gorm.DB, step string, fn func()) (err error) {
+	capture := &genErrorLogger{Interface: db.Logger}
+	g.UseDB(db.Session(&gorm.Session{Logger: capture}))
+	defer g.UseDB(db)
+	defer func() {
+		recovered := recover()
+		if recovered == nil {
+			return
+		}
+		if len(capture.errors) > 0 {
+			err = fmt.Errorf("%s: %s", step, strings.Join(capture.errors, "; "))
+			return
+		}
+		err = fmt.Errorf("%s: %v", step, recovered)
+	}()
+	fn()
+	return nil
+}
+
+// executeGenerator writes the model and query files, returning gen's failure
+// instead of letting it panic.
+func executeGenerator(g *gen.Generator, db *gorm.DB) error {
+	return runGenStep(g, db, "generate code", g.Execute)
+}
+
+var generatedCodePattern = regexp.MustCompile(`(?m)^// Code generated .* DO NOT EDIT\.$`)
+
+// formatGeneratedFiles runs goimports over the generated Go files in OutPath
+// and OutPath/models, so unused imports are dropped, resolvable missing ones
+// are added, and the layout is gofmt's. Files without a "Code generated"
+// header are left alone. Every file is formatted in memory before any is
+// rewritten, so a failure names the offending files and changes nothing.
+func formatGeneratedFiles(outPath string) error {
+	type formattedFile struct {
+		path string
+		src  []byte
+	}
+	var pending []formattedFile
+	var failures []string
+	for _, dir := range []string{outPath, filepath.Join(outPath, "models")} {
+		entries, err := os.ReadDir(dir)
+		if err != nil {
+			if os.IsNotExist(err) {
+				continue
+			}
+			return fmt.Errorf("read output directory %s: %w", dir, err)
+		}
+		for _, entry := range entries {
+			if entry.IsDir() || !strings.HasSuffix(entry.Name(), ".go") {
+				continue
+			}
+			path := filepath.Join(dir, entry.Name())
+			src, err := os.ReadFile(path)
+			if err != nil {
+				return fmt.Errorf("read generated file %s: %w", path, err)
+			}
+			if !generatedCodePattern.Match(src) {
+				continue
+			}
+			formatted, err := imports.Process(path, src, nil)
+			if err != nil {
+				failures = append(failures, fmt.Sprintf("%s: %v", path, err))
+				continue
+			}
+			if !bytes.Equal(formatted, src) {
+				pending = append(pending, formattedFile{path: path, src: formatted})
+			}
+		}
+	}
+	if len(failures) > 0 {
+		return fmt.Errorf("format generated files:\n  %s", strings.Join(failures, "\n  "))
+	}
+
+	for _, file := range pending {
+		if err := os.WriteFile(file.path, file.src, 0o644); err != nil {
+			return fmt.Errorf("write generated file %s: %w", file.path, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,71 @@
+package generator
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/dan-sherwin/gormdb2struct/internal/config"
+	"github.com/glebarez/sqlite"
+	"gorm.io/gorm"
+)
+
+func TestRunGenStepReturnsGenFailures(t *testing.T) {
+	t.Parallel()
+
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	if err != nil {
+		t.Fatalf("open in-memory SQLite: %v", err)
+	}
+	g := newGenerator(config.Config{DatabaseDialect: config.SQLite, OutPath: filepath.Join(t.TempDir(), "generated")})
+	g.UseDB(db)
+
+	err = runGenStep(g, db, "apply models", func() { g.ApplyBasic(42) })
+	if err == nil || !strings.HasPrefix(err.Error(), "apply models: check struct fail") {
+		t.Fatalf("expected gen's panic to come back as an error, got %v", err)
+	}
+	if err := runGenStep(g, db, "apply models", func() {}); err != nil {
+		t.Fatalf("expected a successful step to return nil, got %v", err)
+	}
+}
+
+func TestFormatGeneratedFilesRunsGoimports(t *testing.T) {
+	t.Parallel()
+
+	outPath := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(outPath, "models"), 0o755); err != nil {
+		t.Fatalf("mkdir models: %v", err)
+	}
+	generated := filepath.Join(outPath, "models", "widget.gen.go")
+	handWritten := filepath.Join(outPath, "custom.go")
+	for path, content := range map[string]string{
+		generated:   "// Code generated by gorm.io/gen. DO NOT EDIT.\npackage models\n\nimport (\n\"fmt\"\n\"strings\"\n)\n\nfunc  Name() string { return strings.ToUpper(\"widget\") }\n",
+		handWritten: "package generated\n\nimport \"fmt\"\n\nfunc  Custom() {}\n",
+	} {
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatalf("write %s: %v", path, err)
+		}
+	}
+
+	if err := formatGeneratedFiles(outPath); err != nil {
+		t.Fatalf("format generated files: %v", err)
+	}
+	assertFileContains(t, generated, "\t\"strings\"\n)\n\nfunc Name() string")
+	assertFileNotContains(t, generated, `"fmt"`)
+	assertFileContains(t, handWritten, "func  Custom() {}")
+
+	broken := filepath.Join(outPath, "db.go")
+	if err := os.WriteFile(broken, []byte("// Code generated by gormdb2struct; DO NOT EDIT.\npackage generated\n\nfunc {\n"), 0o644); err != nil {
+		t.Fatalf("write broken file: %v", err)
+	}
+	unformatted := filepath.Join(outPath, "gen.go")
+	if err := os.WriteFile(unformatted, []byte("// Code generated by gorm.io/gen. DO NOT EDIT.\npackage generated\n\nfunc  Use() {}\n"), 0o644); err != nil {
+		t.Fatalf("write unformatted file: %v", err)
+	}
+	err := formatGeneratedFiles(outPath)
+	if err == nil || !strings.Contains(err.Error(), broken+":") {
+		t.Fatalf("expected the broken file to be named in the error, got %v", err)
+	}
+	assertFileContains(t, unformatted, "func  Use() {}")
+}
@@ -0,0 +1,274 @@
+package generator
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/dan-sherwin/gormdb2struct/internal/config"
+	"github.com/dan-sherwin/gormdb2struct/sqlitetype"
+	"github.com/iancoleman/strcase"
+	"github.com/jinzhu/inflection"
+	"gorm.io/gen"
+	"gorm.io/gen/field"
+	"gorm.io/gorm"
+)
+
+// foreignKey is one foreign key constraint with its columns in key order.
+type foreignKey struct {
+	Table      string
+	Columns    []string
+	RefTable   string
+	RefColumns []string
+}
+
+// loadPostgresForeignKeys reads the foreign keys declared on public tables.
+func loadPostgresForeignKeys(db *gorm.DB) ([]foreignKey, error) {
+	type foreignKeyRow struct {
+		TableName    string `gorm:"column:table_name"`
+		Columns      string `gorm:"column:columns"`
+		RefTableName string `gorm:"column:ref_table_name"`
+		RefColumns   string `gorm:"column:ref_columns"`
+	}
+
+	var rows []foreignKeyRow
+	if err := db.Raw(`
+		SELECT
+			src.relname AS table_name,
+			(
+				SELECT string_agg(a.attname, ',' ORDER BY k.ord)
+				FROM unnest(con.conkey) WITH ORDINALITY AS k(attnum, ord)
+				JOIN pg_attribute a ON a.attrelid = con.conrelid AND a.attnum = k.attnum
+			) AS columns,
+			dst.relname AS ref_table_name,
+			(
+				SELECT string_agg(a.attname, ',' ORDER BY k.ord)
+				FROM unnest(con.confkey) WITH ORDINALITY AS k(attnum, ord)
+				JOIN pg_attribute a ON a.attrelid = con.confrelid AND a.attnum = k.attnum
+			) AS ref_columns
+		FROM pg_constraint con
+		JOIN pg_class src ON src.oid = con.conrelid
+		JOIN pg_namespace n ON n.oid = src.relnamespace
+		JOIN pg_class dst ON dst.oid = con.confrelid
+		WHERE con.contype = 'f'
+		  AND n.nspname = 'public'
+		ORDER BY src.relname, con.conname
+	`).Scan(&rows).Error; err != nil {
+		return nil, fmt.Errorf("load PostgreSQL foreign keys: %w", err)
+	}
+
+	keys := make([]foreignKey, 0, len(rows))
+	for _, row := range rows {
+		keys = append(keys, foreignKey{
+			Table:      row.TableName,
+			Columns:    strings.Split(row.Columns, ","),
+			RefTable:   row.RefTableName,
+			RefColumns: strings.Split(row.RefColumns, ","),
+		})
+	}
+	return keys, nil
+}
+
+// loadSQLiteForeignKeys reads the foreign keys declared on the given tables. A
+// key written as REFERENCES parent, without columns, references the parent's
+// primary key, so its RefColumns are filled in from that key.
+func loadSQLiteForeignKeys(db *gorm.DB, tableNames []string) ([]foreignKey, error) {
+	var keys []foreignKey
+	primaryKeys := map[string][]string{}
+	for _, tableName := range tableNames {
+		tableKeys, err := sqlitetype.LoadForeignKeys(db, tableName)
+		if err != nil {
+			return nil, err
+		}
+		for _, key := range tableKeys {
+			if len(key.RefColumns) == 0 {
+				refColumns, loaded := primaryKeys[key.RefTable]
+				if !loaded {
+					if refColumns, err = sqlitePrimaryKeyColumns(db, key.RefTable); err != nil {
+						return nil, err
+					}
+					primaryKeys[key.RefTable] = refColumns
+				}
+				key.RefColumns = refColumns
+			}
+			keys = append(keys, foreignKey(key))
+		}
+	}
+	return keys, nil
+}
+
+// sqlitePrimaryKeyColumns returns the primary key columns of tableName in key
+// order, or none when the table has no declared primary key.
+func sqlitePrimaryKeyColumns(db *gorm.DB, tableName string) ([]string, error) {
+	type columnRow struct {
+		Name string `gorm:"column:name"`
+		PK   int    `gorm:"column:pk"`
+	}
+
+	var rows []columnRow
+	if err := db.Raw("PRAGMA table_info(" + quoteIdent(tableName) + ")").Scan(&rows).Error; err != nil {
+		return nil, fmt.Errorf("load sqlite primary key of %s: %w", tableName, err)
+	}
+	sort.SliceStable(rows, func(i, j int) bool { return rows[i].PK < rows[j].PK })
+	var columns []string
+	for _, row := range rows {
+		if row.PK > 0 {
+			columns = append(columns, row.Name)
+		}
+	}
+	return columns, nil
+}
+
+// detectedRelation is one relation field synthesized from a foreign key
+// before it is attached to its model.
+type detectedRelation struct {
+	Table string
+	Field config.ExtraField
+	Kind  field.RelationshipType
+}
+
+// applyDetectedRelations adds, for every foreign key between two generated
+// tables, a belongs-to pointer on the referencing model and a has-many slice
+// on the referenced one. A relation is left out when the table's ExtraFields
+// already declare it, by name or by the same type and foreign key, or when
+// its name is already taken by another field; the returned messages say why.
+func applyDetectedRelations(cfg config.Config, g *gen.Generator, refs []modelRef, keys []foreignKey) []string {
+	tables := make(map[string]modelRef, len(refs))
+	for _, ref := range refs {
+		if !ref.View {
+			tables[ref.TableName] = ref
+		}
+	}
+	keysBetween := map[[2]string]int{}
+	for _, key := range keys {
+		keysBetween[[2]string{key.Table, key.RefTable}]++
+	}
+
+	modelsPackage := modelsPackageName(g)
+	var relations []detectedRelation
+	var skipped []string
+	for _, key := range keys {
+		child, childOK := tables[key.Table]
+		parent, parentOK := tables[key.RefTable]
+		if !childOK || !parentOK || len(key.Columns) == 0 {
+			continue
+		}
+		if len(key.Columns) != len(key.RefColumns) {
+			skipped = append(skipped, fmt.Sprintf("%s(%s): referenced columns of %s could not be resolved", key.Table, strings.Join(key.Columns, ", "), key.RefTable))
+			continue
+		}
+		foreignFields, ok := relationFieldNames(*child.Fields, key.Columns)
+		if !ok {
+			skipped = append(skipped, fmt.Sprintf("%s(%s): foreign key columns not found on %s", key.Table, strings.Join(key.Columns, ", "), child.StructName))
+			continue
+		}
+		referencedFields, ok := relationFieldNames(*parent.Fields, key.RefColumns)
+		if !ok {
+			skipped = append(skipped, fmt.Sprintf("%s(%s): referenced columns not found on %s", key.Table, strings.Join(key.Columns, ", "), parent.StructName))
+			continue
+		}
+
+		belongsToName := parent.StructName
+		if len(key.Columns) == 1 {
+			if trimmed := strings.TrimSuffix(key.Columns[0], "_id"); trimmed != key.Columns[0] && trimmed != "" {
+				belongsToName = strcase.ToCamel(trimmed)
+			}
+		}
+		hasManyName := inflection.Plural(child.StructName)
+		if key.Table == key.RefTable || keysBetween[[2]string{key.Table, key.RefTable}] > 1 {
+			hasManyName = belongsToName + hasManyName
+		}
+
+		foreignKey := strings.Join(foreignFields, ",")
+		references := strings.Join(referencedFields, ",")
+		relations = append(relations,
+			detectedRelation{
+				Table: key.Table,
+				Kind:  field.BelongsTo,
+				Field: config.ExtraField{
+					StructPropName:    belongsToName,
+					StructPropType:    modelsPackage + "." + parent.StructName,
+					FkStructPropName:  foreignKey,
+					RefStructPropName: references,
+					Pointer:           true,
+				},
+			},
+			detectedRelation{
+				Table: key.RefTable,
+				Kind:  field.HasMany,
+				Field: config.ExtraField{
+					StructPropName:    hasManyName,
+					StructPropType:    modelsPackage + "." + child.StructName,
+					FkStructPropName:  foreignKey,
+					RefStructPropName: references,
+					HasMany:           true,
+				},
+			},
+		)
+	}
+
+	for _, relation := range relations {
+		ref := tables[relation.Table]
+		if declared := declaredExtraField(cfg.ExtraFields[relation.Table], relation.Field); declared != "" {
+			skipped = append(skipped, fmt.Sprintf("%s.%s: declared by ExtraFields %s", ref.StructName, relation.Field.StructPropName, declared))
+			continue
+		}
+		if fieldNameTaken(*ref.Fields, relation.Field.StructPropName) {
+			skipped = append(skipped, fmt.Sprintf("%s.%s: a field with that name already exists", ref.StructName, relation.Field.StructPropName))
+			continue
+		}
+		fld := gen.Field(gen.FieldNew("", "", nil)(nil))
+		genRelationField(relation.Field, fld, cfg.JSONTagStrategy)
+		fld.Relation = field.NewRelationWithType(relation.Kind, relation.Field.StructPropName, relation.Field.StructPropType)
+		*ref.Fields = append(*ref.Fields, fld)
+	}
+	return skipped
+}
+
+// relationFieldNames maps columns to the Go field names of a model.
+func relationFieldNames(fields []gen.Field, columns []string) ([]string, bool) {
+	names := make([]string, 0, len(columns))
+	for _, column := range columns {
+		fld := findFieldByColumn(fields, column)
+		if fld == nil {
+			return nil, false
+		}
+		names = append(names, fld.Name)
+	}
+	return names, true
+}
+
+// declaredExtraField returns the name of the ExtraFields entry that covers a
+// detected relation: one with the same field name, or one of the same type
+// over the same foreign key.
+func declaredExtraField(extraFields []config.ExtraField, detected config.ExtraField) string {
+	detectedType := relationBaseType(detected.StructPropType)
+	for _, extraField := range extraFields {
+		if extraField.StructPropName == detected.StructPropName {
+			return extraField.StructPropName
+		}
+		if relationBaseType(extraField.StructPropType) == detectedType &&
+			extraField.HasMany == detected.HasMany &&
+			strings.ReplaceAll(extraField.FkStructPropName, " ", "") == detected.FkStructPropName {
+			return extraField.StructPropName
+		}
+	}
+	return ""
+}
+
+func relationBaseType(structPropType string) string {
+	structPropType = strings.TrimLeft(structPropType, "*[]")
+	if idx := strings.LastIndex(structPropType, "."); idx != -1 {
+		return structPropType[idx+1:]
+	}
+	return structPropType
+}
+
+func fieldNameTaken(fields []gen.Field, name string) bool {
+	for _, fld := range fields {
+		if fld != nil && fld.Name == name {
+			return true
+		}
+	}
+	return false
+}
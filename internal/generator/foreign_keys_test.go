@@ -0,0 +1,205 @@
+package generator
+
+import (
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/dan-sherwin/gormdb2struct/internal/config"
+	"github.com/glebarez/sqlite"
+	"gorm.io/gen"
+	"gorm.io/gen/field"
+	"gorm.io/gorm"
+)
+
+func TestApplyDetectedRelationsAddsBothSides(t *testing.T) {
+	t.Parallel()
+
+	g := newTestGenerator(t)
+	users := []gen.Field{newTestField("ID", "id", "int64", nil)}
+	posts := []gen.Field{
+		newTestField("ID", "id", "int64", nil),
+		newTestField("AuthorID", "author_id", "int64", nil),
+		newTestField("EditorID", "editor_id", "*int64", nil),
+	}
+	categories := []gen.Field{
+		newTestField("ID", "id", "int64", nil),
+		newTestField("ParentID", "parent_id", "*int64", nil),
+	}
+	refs := []modelRef{
+		{TableName: "users", StructName: "User", Fields: &users},
+		{TableName: "posts", StructName: "Post", Fields: &posts},
+		{TableName: "categories", StructName: "Category", Fields: &categories},
+	}
+	keys := []foreignKey{
+		{Table: "posts", Columns: []string{"author_id"}, RefTable: "users", RefColumns: []string{"id"}},
+		{Table: "posts", Columns: []string{"editor_id"}, RefTable: "users", RefColumns: []string{"id"}},
+		{Table: "categories", Columns: []string{"parent_id"}, RefTable: "categories", RefColumns: []string{"id"}},
+	}
+
+	if skipped := applyDetectedRelations(config.Config{}, g, refs, keys); len(skipped) != 0 {
+		t.Fatalf("expected no skipped relations, got %v", skipped)
+	}
+
+	assertRelationField(t, posts, "Author", "*User", field.BelongsTo, "foreignKey:AuthorID;references:ID")
+	assertRelationField(t, posts, "Editor", "*User", field.BelongsTo, "foreignKey:EditorID;references:ID")
+	assertRelationField(t, users, "AuthorPosts", "[]Post", field.HasMany, "foreignKey:AuthorID;references:ID")
+	assertRelationField(t, users, "EditorPosts", "[]Post", field.HasMany, "foreignKey:EditorID;references:ID")
+	assertRelationField(t, categories, "Parent", "*Category", field.BelongsTo, "foreignKey:ParentID;references:ID")
+	assertRelationField(t, categories, "ParentCategories", "[]Category", field.HasMany, "foreignKey:ParentID;references:ID")
+	if got := findRelationField(users, "AuthorPosts").Relation.Type(); got != "models.Post" {
+		t.Fatalf("expected relation type models.Post, got %q", got)
+	}
+}
+
+func TestLoadSQLiteForeignKeysResolvesImplicitReferences(t *testing.T) {
+	t.Parallel()
+
+	db, err := gorm.Open(sqlite.Open(filepath.Join(t.TempDir(), "fk.db")), &gorm.Config{})
+	if err != nil {
+		t.Fatalf("open SQLite fixture: %v", err)
+	}
+	for _, statement := range []string{
+		`CREATE TABLE team (code TEXT, season INTEGER, name TEXT, PRIMARY KEY (season, code))`,
+		`CREATE TABLE player (id INTEGER PRIMARY KEY, team_season INTEGER, team_code TEXT, FOREIGN KEY (team_season, team_code) REFERENCES team)`,
+		`CREATE TABLE note (id INTEGER PRIMARY KEY, body TEXT)`,
+		`CREATE TABLE tag (id INTEGER PRIMARY KEY, note_rowid INTEGER REFERENCES note)`,
+	} {
+		if err := db.Exec(statement).Error; err != nil {
+			t.Fatalf("create fixture: %v", err)
+		}
+	}
+
+	keys, err := loadSQLiteForeignKeys(db, []string{"player", "tag"})
+	if err != nil {
+		t.Fatalf("load foreign keys: %v", err)
+	}
+	want := []foreignKey{
+		{Table: "player", Columns: []string{"team_season", "team_code"}, RefTable: "team", RefColumns: []string{"season", "code"}},
+		{Table: "tag", Columns: []string{"note_rowid"}, RefTable: "note", RefColumns: []string{"id"}},
+	}
+	if !reflect.DeepEqual(keys, want) {
+		t.Fatalf("expected implicit references resolved to the parent primary key, got %+v", keys)
+	}
+}
+
+func TestApplyDetectedRelationsReportsUnresolvedReferences(t *testing.T) {
+	t.Parallel()
+
+	g := newTestGenerator(t)
+	teams := []gen.Field{newTestField("Name", "name", "string", nil)}
+	players := []gen.Field{newTestField("TeamName", "team_name", "string", nil)}
+	refs := []modelRef{
+		{TableName: "team", StructName: "Team", Fields: &teams},
+		{TableName: "player", StructName: "Player", Fields: &players},
+	}
+	keys := []foreignKey{{Table: "player", Columns: []string{"team_name"}, RefTable: "team"}}
+
+	skipped := applyDetectedRelations(config.Config{}, g, refs, keys)
+	if len(skipped) != 1 || skipped[0] != "player(team_name): referenced columns of team could not be resolved" {
+		t.Fatalf("expected the unresolved key to be reported, got %v", skipped)
+	}
+	if len(players) != 1 || len(teams) != 1 {
+		t.Fatal("expected no relation fields for the unresolved key")
+	}
+}
+
+func TestApplyDetectedRelationsSkipsDeclaredAndTakenNames(t *testing.T) {
+	t.Parallel()
+
+	g := newTestGenerator(t)
+	orders := []gen.Field{
+		newTestField("TenantID", "tenant_id", "int64", nil),
+		newTestField("ID", "id", "int64", nil),
+	}
+	lines := []gen.Field{
+		newTestField("TenantID", "tenant_id", "int64", nil),
+		newTestField("OrderID", "order_id", "int64", nil),
+		newTestField("Order", "order", "string", nil),
+	}
+	refs := []modelRef{
+		{TableName: "orders", StructName: "Order", Fields: &orders},
+		{TableName: "order_lines", StructName: "OrderLine", Fields: &lines},
+		{TableName: "order_summary", StructName: "OrderSummary", View: true, Fields: &[]gen.Field{}},
+	}
+	keys := []foreignKey{
+		{Table: "order_lines", Columns: []string{"tenant_id", "order_id"}, RefTable: "orders", RefColumns: []string{"tenant_id", "id"}},
+		{Table: "order_summary", Columns: []string{"order_id"}, RefTable: "orders", RefColumns: []string{"id"}},
+		{Table: "order_lines", Columns: []string{"product_id"}, RefTable: "products", RefColumns: []string{"id"}},
+	}
+	cfg := config.Config{ExtraFields: map[string][]config.ExtraField{
+		"orders": {{
+			StructPropName:    "Lines",
+			StructPropType:    "models.OrderLine",
+			FkStructPropName:  "TenantID, OrderID",
+			RefStructPropName: "TenantID,ID",
+			HasMany:           true,
+		}},
+	}}
+	appendExtraFields(&orders, cfg.ExtraFields["orders"], cfg.JSONTagStrategy)
+
+	skipped := applyDetectedRelations(cfg, g, refs, keys)
+	if len(skipped) != 2 ||
+		!strings.Contains(skipped[0], "OrderLine.Order: a field with that name already exists") ||
+		!strings.Contains(skipped[1], "Order.OrderLines: declared by ExtraFields Lines") {
+		t.Fatalf("unexpected skipped relations: %v", skipped)
+	}
+	if len(orders) != 3 || len(lines) != 3 {
+		t.Fatalf("expected no detected fields, got %d order and %d line fields", len(orders), len(lines))
+	}
+}
+
+func TestApplyDetectedRelationsJoinsCompositeKeys(t *testing.T) {
+	t.Parallel()
+
+	g := newTestGenerator(t)
+	orders := []gen.Field{
+		newTestField("TenantID", "tenant_id", "int64", nil),
+		newTestField("ID", "id", "int64", nil),
+	}
+	lines := []gen.Field{
+		newTestField("TenantID", "tenant_id", "int64", nil),
+		newTestField("OrderID", "order_id", "int64", nil),
+	}
+	refs := []modelRef{
+		{TableName: "orders", StructName: "Order", Fields: &orders},
+		{TableName: "order_lines", StructName: "OrderLine", Fields: &lines},
+	}
+	keys := []foreignKey{
+		{Table: "order_lines", Columns: []string{"tenant_id", "order_id"}, RefTable: "orders", RefColumns: []string{"tenant_id", "id"}},
+	}
+
+	if skipped := applyDetectedRelations(config.Config{}, g, refs, keys); len(skipped) != 0 {
+		t.Fatalf("expected no skipped relations, got %v", skipped)
+	}
+	assertRelationField(t, lines, "Order", "*Order", field.BelongsTo, "foreignKey:TenantID,OrderID;references:TenantID,ID")
+	assertRelationField(t, orders, "OrderLines", "[]OrderLine", field.HasMany, "foreignKey:TenantID,OrderID;references:TenantID,ID")
+}
+
+func findRelationField(fields []gen.Field, name string) gen.Field {
+	for _, fld := range fields {
+		if fld.IsRelation() && fld.Name == name {
+			return fld
+		}
+	}
+	return nil
+}
+
+func assertRelationField(t *testing.T, fields []gen.Field, name, goType string, kind field.RelationshipType, gormTag string) {
+	t.Helper()
+
+	fld := findRelationField(fields, name)
+	if fld == nil {
+		t.Fatalf("expected relation field %s", name)
+	}
+	if fld.Type != goType {
+		t.Fatalf("expected %s to have type %s, got %s", name, goType, fld.Type)
+	}
+	if fld.Relation.Relationship() != kind {
+		t.Fatalf("expected %s to be %s, got %s", name, kind, fld.Relation.Relationship())
+	}
+	if got := fld.GORMTag.Build(); got != gormTag {
+		t.Fatalf("expected %s gorm tag %q, got %q", name, gormTag, got)
+	}
+}
@@ -21,17 +21,22 @@ type generatedTypesPackage struct {
 	PackagePath string
 	OutputDir   string
 	TypeMap     map[string]string
+	// ColumnTypes maps table and column to the generated type of each
+	// CheckEnums column. Those types replace single fields rather than a
+	// database type, so they cannot go through TypeMap.
+	ColumnTypes map[string]map[string]string
 	Enums       []generatedEnumType
 	Domains     []generatedDomainType
 	Arrays      []generatedArrayType
 }
 
 type generatedEnumType struct {
-	DBType    string
-	GoType    string
-	FileName  string
-	Labels    []string
-	Constants []generatedEnumConstant
+	DBType      string
+	GoType      string
+	FileName    string
+	Labels      []string
+	Constants   []generatedEnumConstant
+	CheckColumn string
 }
 
 type generatedEnumConstant struct {
@@ -70,6 +75,8 @@ func (t generatedArrayType) getGoType() string {
 
 var (
 	pgConstraintRegexPattern = regexp.MustCompile(`~\*?\s*'((?:[^'\\]|\\.)*)'`)
+	pgCheckInListPattern     = regexp.MustCompile(`(?is)^CHECK\s*\(\s*\(*"?([A-Za-z_][A-Za-z0-9_]*)"?\)*(?:::[a-z ]+)?\s*(?:=\s*ANY\s*\(\s*\(*ARRAY\[((?:'(?:[^']|'')*'|[^'\]])*)\]|IN\s*\(((?:'(?:[^']|'')*'|[^')])*)\))[\s)]*(?:::[a-z ]+\[\])?[\s)]*$`)
+	pgCheckLiteralPattern    = regexp.MustCompile(`'((?:[^']|'')*)'(?:::[a-z ]+)*`)
 	qualifiedTypePattern     = regexp.MustCompile(`\b([A-Za-z_][A-Za-z0-9_]*)\.[A-Za-z_][A-Za-z0-9_]*`)
 	knownTypeImportPaths     = map[string]string{
 		"datatypes": "gorm.io/datatypes",
@@ -81,26 +88,42 @@ var (
 	}
 )
 
-func preparePostgresGeneratedTypes(cfg config.Config, db *gorm.DB) (config.Config, error) {
+// preparePostgresGeneratedTypes writes the generated types package and returns
+// the config with its TypeMap entries merged in, plus the per-column types of
+// CheckEnums keyed by table and column.
+func preparePostgresGeneratedTypes(cfg config.Config, db *gorm.DB, objects []postgresObject) (config.Config, map[string]map[string]string, error) {
 	if !cfg.GeneratedTypes.HasEntries() {
-		return cfg, nil
+		return cfg, nil, nil
 	}
 
 	enumMeta, err := loadPostgresEnumMetadata(db)
 	if err != nil {
-		return cfg, err
+		return cfg, nil, err
+	}
+	if cfg.GeneratedTypes.GenerateEnumTypes {
+		columns, err := loadPostgresInspectionColumns(db, objects)
+		if err != nil {
+			return cfg, nil, err
+		}
+		cfg.GeneratedTypes.TypeMap = withDetectedEnumTypes(cfg, columns, enumMeta)
 	}
 	domainMeta, err := loadPostgresDomainMetadata(db)
 	if err != nil {
-		return cfg, err
+		return cfg, nil, err
+	}
+	var checkMeta map[string]postgresCheckConstraintMetadata
+	if len(cfg.GeneratedTypes.CheckEnums) > 0 {
+		if checkMeta, err = loadPostgresCheckConstraints(db); err != nil {
+			return cfg, nil, err
+		}
 	}
 
-	pkg, err := buildGeneratedTypesPackage(cfg, enumMeta, domainMeta)
+	pkg, err := buildGeneratedTypesPackage(cfg, enumMeta, domainMeta, checkMeta)
 	if err != nil {
-		return cfg, err
+		return cfg, nil, err
 	}
 	if err := writeGeneratedTypesPackage(pkg); err != nil {
-		return cfg, err
+		return cfg, nil, err
 	}
 
 	effective := cfg
@@ -112,15 +135,21 @@ func preparePostgresGeneratedTypes(cfg config.Config, db *gorm.DB) (config.Confi
 	filteredImports := filterImportPathsByAlias(cfg.ImportPackagePaths, pkg.PackageName, pkg.PackagePath)
 	effective.ImportPackagePaths = mergeImportPaths(filteredImports, []string{pkg.PackagePath})
 
-	return effective, nil
+	return effective, pkg.ColumnTypes, nil
 }
 
-func buildGeneratedTypesPackage(cfg config.Config, enumMeta map[string]postgresEnumMetadata, domainMeta map[string]postgresDomainMetadata) (generatedTypesPackage, error) {
+func buildGeneratedTypesPackage(
+	cfg config.Config,
+	enumMeta map[string]postgresEnumMetadata,
+	domainMeta map[string]postgresDomainMetadata,
+	checkMeta map[string]postgresCheckConstraintMetadata,
+) (generatedTypesPackage, error) {
 	pkg := generatedTypesPackage{
 		PackageName: cfg.GeneratedTypes.PackageName,
 		PackagePath: resolveGeneratedTypesPackagePath(cfg),
 		OutputDir:   filepath.Join(cfg.OutPath, cfg.GeneratedTypes.RelativePath),
 		TypeMap:     make(map[string]string, len(cfg.GeneratedTypes.TypeMap)),
+		ColumnTypes: make(map[string]map[string]string),
 	}
 
 	seenTypeNames := make(map[string]string, len(cfg.GeneratedTypes.TypeMap))
@@ -212,6 +241,39 @@ func buildGeneratedTypesPackage(cfg config.Config, enumMeta map[string]postgresE
 		})
 	}
 
+	for column, configuredGoType := range cfg.GeneratedTypes.CheckEnums {
+		qualifiedGoType, goTypeName, err := normalizeGeneratedGoType(pkg.PackageName, configuredGoType)
+		if err != nil {
+			return generatedTypesPackage{}, fmt.Errorf("GeneratedTypes.CheckEnums[%q]: %w", column, err)
+		}
+		meta, ok := checkMeta[column]
+		if !ok {
+			return generatedTypesPackage{}, fmt.Errorf("GeneratedTypes.CheckEnums[%q] has no single-column CHECK constraint", column)
+		}
+		labels, ok := extractCheckInValues(meta.ConstraintDef, meta.ColumnName)
+		if !ok {
+			return generatedTypesPackage{}, fmt.Errorf("GeneratedTypes.CheckEnums[%q] has no CHECK constraint of the form %s IN (...)", column, meta.ColumnName)
+		}
+		if err := registerGeneratedTypeName(seenTypeNames, goTypeName, "check:"+meta.canonicalName()); err != nil {
+			return generatedTypesPackage{}, err
+		}
+		if pkg.ColumnTypes[meta.TableName] == nil {
+			pkg.ColumnTypes[meta.TableName] = make(map[string]string)
+		}
+		pkg.ColumnTypes[meta.TableName][meta.ColumnName] = qualifiedGoType
+		if generatedTypeExists(pkg.Enums, goTypeName) {
+			continue
+		}
+		pkg.Enums = append(pkg.Enums, generatedEnumType{
+			DBType:      meta.ColumnType,
+			GoType:      goTypeName,
+			FileName:    generatedTypeFileName(goTypeName),
+			Labels:      labels,
+			Constants:   buildEnumConstants(goTypeName, labels),
+			CheckColumn: meta.canonicalName(),
+		})
+	}
+
 	sort.Slice(pkg.Enums, func(i, j int) bool { return pkg.Enums[i].FileName < pkg.Enums[j].FileName })
 	sort.Slice(pkg.Domains, func(i, j int) bool { return pkg.Domains[i].FileName < pkg.Domains[j].FileName })
 	sort.Slice(pkg.Arrays, func(i, j int) bool { return pkg.Arrays[i].FileName < pkg.Arrays[j].FileName })
@@ -219,6 +281,77 @@ func buildGeneratedTypesPackage(cfg config.Config, enumMeta map[string]postgresE
 	return pkg, nil
 }
 
+// withDetectedEnumTypes returns GeneratedTypes.TypeMap plus an entry for every
+// enum and enum array in columns that neither TypeMap maps. Names follow the
+// inspect suggestions, so order_status becomes OrderStatus and order_status[]
+// OrderStatusArray. An enum shared by several objects gets a single entry.
+func withDetectedEnumTypes(cfg config.Config, columns []postgresInspectionColumnRow, enumMeta map[string]postgresEnumMetadata) map[string]string {
+	typeMap := cloneStringMap(cfg.GeneratedTypes.TypeMap)
+	usedNames := make(map[string]struct{}, len(typeMap)+len(cfg.GeneratedTypes.CheckEnums))
+	for _, goType := range typeMap {
+		usedNames[unqualifiedTypeName(goType)] = struct{}{}
+	}
+	for _, goType := range cfg.GeneratedTypes.CheckEnums {
+		usedNames[unqualifiedTypeName(goType)] = struct{}{}
+	}
+
+	var enums, arrays []string
+	for _, column := range columns {
+		info, ok := classifyPostgresInspectionType(column, enumMeta, nil)
+		if !ok {
+			continue
+		}
+		switch info.Kind {
+		case InspectionTypeEnum:
+			if _, ok := lookupPostgresEnumMetadata(enumMeta, info.DBType); ok {
+				enums = append(enums, info.DBType)
+			}
+		case InspectionTypeEnumArray:
+			if _, ok := lookupPostgresEnumMetadata(enumMeta, info.BaseDBType); ok {
+				arrays = append(arrays, info.DBType)
+			}
+		}
+	}
+	enums = uniqueStrings(enums)
+	arrays = uniqueStrings(arrays)
+	sort.Strings(enums)
+	sort.Strings(arrays)
+
+	mapped := func(dbType string) bool {
+		_, generated := lookupConfiguredType(typeMap, dbType)
+		_, shared := lookupConfiguredType(cfg.TypeMap, dbType)
+		return generated || shared
+	}
+	for _, dbType := range enums {
+		if mapped(dbType) {
+			continue
+		}
+		typeMap[dbType] = allocateGeneratedSuggestionName(suggestGeneratedTypeName(dbType, false), usedNames)
+	}
+	for _, dbType := range arrays {
+		if mapped(dbType) {
+			continue
+		}
+		// Array wrappers are built on the generated base type, so an enum
+		// mapped through the shared TypeMap keeps its array as is.
+		baseGoType, ok := lookupConfiguredType(typeMap, strings.TrimSuffix(dbType, "[]"))
+		if !ok {
+			continue
+		}
+		typeMap[dbType] = allocateGeneratedSuggestionName(unqualifiedTypeName(baseGoType)+"Array", usedNames)
+	}
+
+	return typeMap
+}
+
+func unqualifiedTypeName(goType string) string {
+	goType = strings.TrimSpace(goType)
+	if idx := strings.LastIndex(goType, "."); idx != -1 {
+		return goType[idx+1:]
+	}
+	return goType
+}
+
 func resolveGeneratedTypesPackagePath(cfg config.Config) string {
 	if strings.TrimSpace(cfg.GeneratedTypes.PackagePath) != "" {
 		return cfg.GeneratedTypes.PackagePath
@@ -386,6 +519,32 @@ func extractConstraintRegex(constraints []string) string {
 	return ""
 }
 
+// extractCheckInValues returns the values of the first constraint that limits
+// column to a literal list, the column IN ('a', 'b') pattern that
+// pg_get_constraintdef reports as column = ANY (ARRAY['a'::text, 'b'::text]).
+func extractCheckInValues(constraints []string, column string) ([]string, bool) {
+	for _, constraint := range constraints {
+		match := pgCheckInListPattern.FindStringSubmatch(strings.TrimSpace(constraint))
+		if match == nil || match[1] != column {
+			continue
+		}
+		list := match[2] + match[3]
+		if strings.Trim(pgCheckLiteralPattern.ReplaceAllString(list, ""), " ,\n\t") != "" {
+			continue
+		}
+		literals := pgCheckLiteralPattern.FindAllStringSubmatch(list, -1)
+		if len(literals) == 0 {
+			continue
+		}
+		values := make([]string, 0, len(literals))
+		for _, literal := range literals {
+			values = append(values, strings.ReplaceAll(literal[1], "''", "'"))
+		}
+		return uniqueStrings(values), true
+	}
+	return nil, false
+}
+
 func buildEnumConstants(goType string, labels []string) []generatedEnumConstant {
 	constants := make([]generatedEnumConstant, 0, len(labels))
 	usedNames := make(map[string]int, len(labels))
@@ -138,3 +138,60 @@ func loadPostgresDomainMetadata(db *gorm.DB) (map[string]postgresDomainMetadata,
 
 	return out, nil
 }
+
+type postgresCheckConstraintMetadata struct {
+	TableName     string
+	ColumnName    string
+	ColumnType    string
+	ConstraintDef []string
+}
+
+func (m postgresCheckConstraintMetadata) canonicalName() string {
+	return m.TableName + "." + m.ColumnName
+}
+
+// loadPostgresCheckConstraints returns the single-column CHECK constraints of
+// the public tables keyed by "table.column".
+func loadPostgresCheckConstraints(db *gorm.DB) (map[string]postgresCheckConstraintMetadata, error) {
+	var rows []struct {
+		TableName     string `gorm:"column:table_name"`
+		ColumnName    string `gorm:"column:column_name"`
+		ColumnType    string `gorm:"column:column_type"`
+		ConstraintDef string `gorm:"column:constraint_def"`
+	}
+
+	if err := db.Raw(`
+		SELECT
+			cl.relname AS table_name,
+			a.attname AS column_name,
+			format_type(a.atttypid, a.atttypmod) AS column_type,
+			pg_get_constraintdef(c.oid, true) AS constraint_def
+		FROM pg_constraint c
+		JOIN pg_class cl ON cl.oid = c.conrelid
+		JOIN pg_namespace ns ON ns.oid = cl.relnamespace
+		JOIN pg_attribute a ON a.attrelid = c.conrelid AND a.attnum = c.conkey[1]
+		WHERE c.contype = 'c'
+		  AND ns.nspname = 'public'
+		  AND array_length(c.conkey, 1) = 1
+		ORDER BY cl.relname, a.attname, c.conname
+	`).Scan(&rows).Error; err != nil {
+		return nil, fmt.Errorf("load PostgreSQL check constraints: %w", err)
+	}
+
+	out := make(map[string]postgresCheckConstraintMetadata, len(rows))
+	for _, row := range rows {
+		key := row.TableName + "." + row.ColumnName
+		meta, exists := out[key]
+		if !exists {
+			meta = postgresCheckConstraintMetadata{
+				TableName:  row.TableName,
+				ColumnName: row.ColumnName,
+				ColumnType: row.ColumnType,
+			}
+		}
+		meta.ConstraintDef = append(meta.ConstraintDef, strings.TrimSpace(row.ConstraintDef))
+		out[key] = meta
+	}
+
+	return out, nil
+}
@@ -184,7 +184,7 @@ import (
 	"gorm.io/gorm/schema"
 )
 
-// {{.GoType}} represents the PostgreSQL {{printf "%q" .DBType}} enum.
+// {{.GoType}} represents {{if .CheckColumn}}the values allowed by the CHECK constraint on {{printf "%q" .CheckColumn}}{{else}}the PostgreSQL {{printf "%q" .DBType}} enum{{end}}.
 type {{.GoType}} string
 
 const (
@@ -96,7 +96,7 @@ func TestBuildGeneratedTypesPackageWritesFiles(t *testing.T) {
 		},
 	}
 
-	pkg, err := buildGeneratedTypesPackage(cfg, enumMeta, domainMeta)
+	pkg, err := buildGeneratedTypesPackage(cfg, enumMeta, domainMeta, nil)
 	if err != nil {
 		t.Fatalf("build generated types package: %v", err)
 	}
@@ -149,3 +149,191 @@ func assertFileContains(t *testing.T, path string, substring string) {
 		t.Fatalf("expected %s to contain %q", path, substring)
 	}
 }
+
+func TestExtractCheckInValues(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		constraints []string
+		column      string
+		want        []string
+		wantOK      bool
+	}{
+		{
+			name:        "varchar any array",
+			constraints: []string{"CHECK (status::text = ANY (ARRAY['open'::character varying, 'closed'::character varying]::text[]))"},
+			column:      "status",
+			want:        []string{"open", "closed"},
+			wantOK:      true,
+		},
+		{
+			name:        "parenthesized varchar any array",
+			constraints: []string{"CHECK (((status)::text = ANY ((ARRAY['open'::character varying, 'closed'::character varying])::text[])))"},
+			column:      "status",
+			want:        []string{"open", "closed"},
+			wantOK:      true,
+		},
+		{
+			name:        "text any array with escaped quote",
+			constraints: []string{"CHECK (status = ANY (ARRAY['it''s'::text, 'done'::text]))"},
+			column:      "status",
+			want:        []string{"it's", "done"},
+			wantOK:      true,
+		},
+		{
+			name:        "in list",
+			constraints: []string{"CHECK (status IN ('x', 'y'))"},
+			column:      "status",
+			want:        []string{"x", "y"},
+			wantOK:      true,
+		},
+		{
+			name: "skips unrelated constraint",
+			constraints: []string{
+				"CHECK (length(status) > 2)",
+				"CHECK (status = ANY (ARRAY['a'::text]))",
+			},
+			column: "status",
+			want:   []string{"a"},
+			wantOK: true,
+		},
+		{
+			name:        "other column",
+			constraints: []string{"CHECK (priority = ANY (ARRAY['low'::text]))"},
+			column:      "status",
+		},
+		{
+			name:        "non literal list",
+			constraints: []string{"CHECK (status = ANY (ARRAY['a'::text, lower(name)]))"},
+			column:      "status",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, ok := extractCheckInValues(tt.constraints, tt.column)
+			if ok != tt.wantOK {
+				t.Fatalf("expected ok=%t, got %t (values %q)", tt.wantOK, ok, got)
+			}
+			if strings.Join(got, "|") != strings.Join(tt.want, "|") {
+				t.Fatalf("expected values %q, got %q", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestBuildGeneratedTypesPackageCheckEnums(t *testing.T) {
+	t.Parallel()
+
+	cfg := config.Config{
+		OutPath:        filepath.Join(t.TempDir(), "generated"),
+		OutPackagePath: "example.com/generated",
+		GeneratedTypes: config.GeneratedTypesConfig{
+			PackageName:  "types",
+			RelativePath: filepath.Join("models", "types"),
+			CheckEnums: map[string]string{
+				"tickets.priority": "TicketPriority",
+			},
+		},
+	}
+	checkMeta := map[string]postgresCheckConstraintMetadata{
+		"tickets.priority": {
+			TableName:     "tickets",
+			ColumnName:    "priority",
+			ColumnType:    "text",
+			ConstraintDef: []string{"CHECK (priority = ANY (ARRAY['low'::text, 'high'::text]))"},
+		},
+	}
+
+	pkg, err := buildGeneratedTypesPackage(cfg, nil, nil, checkMeta)
+	if err != nil {
+		t.Fatalf("build generated types package: %v", err)
+	}
+	if pkg.ColumnTypes["tickets"]["priority"] != "types.TicketPriority" {
+		t.Fatalf("unexpected column type: %q", pkg.ColumnTypes["tickets"]["priority"])
+	}
+	if len(pkg.TypeMap) != 0 {
+		t.Fatalf("expected check enums to stay out of TypeMap, got %v", pkg.TypeMap)
+	}
+	if len(pkg.Enums) != 1 || pkg.Enums[0].CheckColumn != "tickets.priority" {
+		t.Fatalf("unexpected enums: %+v", pkg.Enums)
+	}
+
+	if err := writeGeneratedTypesPackage(pkg); err != nil {
+		t.Fatalf("write generated types package: %v", err)
+	}
+	assertFileContains(t, filepath.Join(pkg.OutputDir, "ticket_priority.gen.go"), `TicketPriorityHigh TicketPriority = "high"`)
+	assertFileContains(t, filepath.Join(pkg.OutputDir, "ticket_priority.gen.go"), ")\n\n// TicketPriority represents the values allowed by the CHECK constraint on \"tickets.priority\".")
+
+	delete(checkMeta, "tickets.priority")
+	if _, err := buildGeneratedTypesPackage(cfg, nil, nil, checkMeta); err == nil {
+		t.Fatal("expected an error for a column without a CHECK constraint")
+	}
+}
+
+func TestWithDetectedEnumTypesMapsEachUsedEnumOnce(t *testing.T) {
+	t.Parallel()
+
+	enumMeta := map[string]postgresEnumMetadata{
+		"order_status":        {SchemaName: "public", TypeName: "order_status", Labels: []string{"pending", "shipped"}},
+		"public.order_status": {SchemaName: "public", TypeName: "order_status", Labels: []string{"pending", "shipped"}},
+		"priority":            {SchemaName: "public", TypeName: "priority", Labels: []string{"low", "high"}},
+		"public.priority":     {SchemaName: "public", TypeName: "priority", Labels: []string{"low", "high"}},
+		"mood":                {SchemaName: "public", TypeName: "mood", Labels: []string{"ok"}},
+		"public.mood":         {SchemaName: "public", TypeName: "mood", Labels: []string{"ok"}},
+	}
+	columns := []postgresInspectionColumnRow{
+		{ObjectName: "orders", ColumnName: "status", TypeSchema: "public", TypeName: "order_status", TypeKind: "e"},
+		{ObjectName: "order_history", ColumnName: "status", TypeSchema: "public", TypeName: "order_status", TypeKind: "e"},
+		{ObjectName: "orders", ColumnName: "past_statuses", TypeSchema: "pg_catalog", TypeName: "_order_status", TypeKind: "b", ElementSchema: "public", ElementTypeName: "order_status", ElementTypeKind: "e"},
+		{ObjectName: "orders", ColumnName: "priority", TypeSchema: "public", TypeName: "priority", TypeKind: "e"},
+		{ObjectName: "orders", ColumnName: "mood", TypeSchema: "public", TypeName: "mood", TypeKind: "e"},
+		{ObjectName: "orders", ColumnName: "moods", TypeSchema: "pg_catalog", TypeName: "_mood", TypeKind: "b", ElementSchema: "public", ElementTypeName: "mood", ElementTypeKind: "e"},
+		{ObjectName: "orders", ColumnName: "note", TypeSchema: "pg_catalog", TypeName: "text", TypeKind: "b"},
+	}
+	cfg := config.Config{
+		TypeMap: map[string]string{"mood": "string"},
+		GeneratedTypes: config.GeneratedTypesConfig{
+			PackageName:       "dbtypes",
+			TypeMap:           map[string]string{"priority": "dbtypes.OrderStatus"},
+			GenerateEnumTypes: true,
+		},
+	}
+
+	got := withDetectedEnumTypes(cfg, columns, enumMeta)
+	want := map[string]string{
+		"priority":       "dbtypes.OrderStatus",
+		"order_status":   "OrderStatus2",
+		"order_status[]": "OrderStatus2Array",
+	}
+	if len(got) != len(want) {
+		t.Fatalf("unexpected type map: %v", got)
+	}
+	for dbType, goType := range want {
+		if got[dbType] != goType {
+			t.Fatalf("expected %q to map to %q, got %v", dbType, goType, got)
+		}
+	}
+	if len(cfg.GeneratedTypes.TypeMap) != 1 {
+		t.Fatalf("expected the configured TypeMap to stay untouched, got %v", cfg.GeneratedTypes.TypeMap)
+	}
+
+	pkg, err := buildGeneratedTypesPackage(config.Config{
+		OutPath:        filepath.Join(t.TempDir(), "generated"),
+		OutPackagePath: "example.com/generated",
+		GeneratedTypes: config.GeneratedTypesConfig{
+			PackageName:  "dbtypes",
+			RelativePath: filepath.Join("models", "dbtypes"),
+			TypeMap:      got,
+		},
+	}, enumMeta, nil, nil)
+	if err != nil {
+		t.Fatalf("build generated types package: %v", err)
+	}
+	if len(pkg.Enums) != 2 || len(pkg.Arrays) != 1 || pkg.Arrays[0].ElementGoType != "OrderStatus2" {
+		t.Fatalf("unexpected generated types: enums %+v arrays %+v", pkg.Enums, pkg.Arrays)
+	}
+}
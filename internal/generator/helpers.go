@@ -0,0 +1,232 @@
+package generator
+
+import (
+	"log/slog"
+	"path"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/dan-sherwin/gormdb2struct/internal/config"
+	"gorm.io/gen"
+	"gorm.io/gen/field"
+)
+
+// helperModel is the generator-neutral view of one generated model that the
+// optional helper files are rendered from.
+type helperModel struct {
+	StructName       string
+	TableName        string
+	View             bool
+	MaterializedView bool
+	Fields           []gen.Field
+	Relations        []gen.Field
+}
+
+func collectHelperModels(refs []modelRef) []helperModel {
+	models := make([]helperModel, 0, len(refs))
+	for _, ref := range refs {
+		model := helperModel{
+			StructName:       ref.StructName,
+			TableName:        ref.TableName,
+			View:             ref.View,
+			MaterializedView: ref.MaterializedView,
+		}
+		for _, fld := range *ref.Fields {
+			if fld == nil {
+				continue
+			}
+			if fld.IsRelation() {
+				model.Relations = append(model.Relations, fld)
+				continue
+			}
+			model.Fields = append(model.Fields, fld)
+		}
+		models = append(models, model)
+	}
+	sort.Slice(models, func(i, j int) bool { return models[i].StructName < models[j].StructName })
+	return models
+}
+
+type modelIndex struct {
+	Name    string
+	Unique  bool
+	Columns []string
+	Fields  []gen.Field
+}
+
+// modelIndexes regroups the per-field index tags gen emits
+// ("name,priority:N") into whole indexes with their columns in key order.
+func modelIndexes(fields []gen.Field) []modelIndex {
+	type indexColumn struct {
+		Field    gen.Field
+		Priority int
+	}
+
+	byName := map[string]*modelIndex{}
+	columnsByName := map[string][]indexColumn{}
+	for _, fld := range fields {
+		for _, key := range []string{field.TagKeyGormIndex, field.TagKeyGormUniqueIndex} {
+			for _, value := range fld.GORMTag[key] {
+				name, priority := parseIndexTagValue(value)
+				if name == "" {
+					continue
+				}
+				index, ok := byName[name]
+				if !ok {
+					index = &modelIndex{Name: name}
+					byName[name] = index
+				}
+				index.Unique = index.Unique || key == field.TagKeyGormUniqueIndex
+				columnsByName[name] = append(columnsByName[name], indexColumn{Field: fld, Priority: priority})
+			}
+		}
+	}
+
+	indexes := make([]modelIndex, 0, len(byName))
+	for name, index := range byName {
+		columns := columnsByName[name]
+		sort.SliceStable(columns, func(i, j int) bool { return columns[i].Priority < columns[j].Priority })
+		for _, column := range columns {
+			index.Columns = append(index.Columns, column.Field.ColumnName)
+			index.Fields = append(index.Fields, column.Field)
+		}
+		indexes = append(indexes, *index)
+	}
+	sort.Slice(indexes, func(i, j int) bool { return indexes[i].Name < indexes[j].Name })
+	return indexes
+}
+
+func parseIndexTagValue(value string) (string, int) {
+	parts := strings.Split(value, ",")
+	name := strings.TrimSpace(parts[0])
+	priority := 0
+	for _, part := range parts[1:] {
+		if raw, ok := strings.CutPrefix(strings.TrimSpace(part), "priority:"); ok {
+			priority, _ = strconv.Atoi(raw)
+		}
+	}
+	return name, priority
+}
+
+// wellKnownTypeImports resolves qualifiers that gen emits without them being
+// listed in ImportPackagePaths.
+var wellKnownTypeImports = map[string]string{
+	"datatypes": "gorm.io/datatypes",
+	"gorm":      "gorm.io/gorm",
+	"json":      "encoding/json",
+	"sql":       "database/sql",
+	"time":      "time",
+}
+
+// qualifiedTypeImport resolves the package of a qualified Go type such as
+// "datatypes.UUID" against the configured import paths, the same way the
+// generated model files resolve it.
+func qualifiedTypeImport(goType string, importPaths []string) (string, bool) {
+	qualifier, _, ok := strings.Cut(strings.TrimLeft(goType, "*[]"), ".")
+	if !ok {
+		return "", true
+	}
+	for _, importPath := range importPaths {
+		if path.Base(importPath) == qualifier {
+			return importPath, true
+		}
+	}
+	importPath, ok := wellKnownTypeImports[qualifier]
+	return importPath, ok
+}
+
+// helperImports returns the sorted import set of a helper file without the
+// packages its template always imports.
+func helperImports(imports map[string]struct{}, fixed ...string) []string {
+	out := make([]string, 0, len(imports))
+	for importPath := range imports {
+		if importPath != "" && !slices.Contains(fixed, importPath) {
+			out = append(out, importPath)
+		}
+	}
+	sort.Strings(out)
+	return out
+}
+
+func modelsPackageName(g *gen.Generator) string {
+	return filepath.Base(g.ModelPkgPath)
+}
+
+// needsForeignKeys reports whether any enabled option reads foreign keys, so
+// the dialect loops only introspect them when required.
+func needsForeignKeys(cfg config.Config) bool {
+	return cfg.Helpers.GenerateERD || cfg.DetectForeignKeys
+}
+
+func writeHelperFiles(logger *slog.Logger, cfg config.Config, g *gen.Generator, refs []modelRef, foreignKeys []foreignKey) error {
+	if !cfg.Helpers.Enabled() && len(cfg.SensitiveColumns) == 0 {
+		return nil
+	}
+
+	models := collectHelperModels(refs)
+	if cfg.Helpers.GeneratePreloadConstants {
+		if err := writePreloadConstants(g, models); err != nil {
+			return err
+		}
+	}
+	if len(cfg.SensitiveColumns) > 0 {
+		if err := writeSensitiveStringers(g, models, cfg.SensitiveColumns); err != nil {
+			return err
+		}
+	}
+	if cfg.Helpers.GenerateFindOrCreate {
+		if err := writeFindOrCreate(g, models); err != nil {
+			return err
+		}
+	}
+	if cfg.Helpers.GenerateSchemaAssertion {
+		if err := writeSchemaAssertion(g, models); err != nil {
+			return err
+		}
+	}
+	if cfg.Helpers.GenerateScopes {
+		if err := writeScopes(g, models, cfg.ImportPackagePaths); err != nil {
+			return err
+		}
+	}
+	if cfg.Helpers.GenerateUpdateHelpers {
+		if err := writeUpdateHelpers(logger, g, models, cfg.ImportPackagePaths); err != nil {
+			return err
+		}
+	}
+	if cfg.Helpers.GenerateExists {
+		if err := writeExists(g, models, cfg.ImportPackagePaths); err != nil {
+			return err
+		}
+	}
+	if cfg.Helpers.GenerateMapConversion {
+		if err := writeMapConversion(g, models); err != nil {
+			return err
+		}
+	}
+	if cfg.Helpers.GenerateTxHelpers && !cfg.ModelsOnly {
+		if err := writeTxHelpers(g, refs); err != nil {
+			return err
+		}
+	}
+	if column := strings.TrimSpace(cfg.Helpers.TenantColumn); column != "" {
+		if err := writeTenantScope(g, models, column, cfg.Helpers.EnforceTenant, cfg.ImportPackagePaths); err != nil {
+			return err
+		}
+	}
+	if cfg.Helpers.GenerateSchemaDoc {
+		if err := writeSchemaDoc(g.OutPath, models); err != nil {
+			return err
+		}
+	}
+	if cfg.Helpers.GenerateERD {
+		if err := writeERD(g.OutPath, models, foreignKeys); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
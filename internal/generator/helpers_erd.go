@@ -0,0 +1,49 @@
+package generator
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// writeERD writes a Graphviz schema_gen.dot into outPath with a node per
+// generated table or view and an edge per foreign key between them, labeled
+// with the referencing columns.
+func writeERD(outPath string, models []helperModel, foreignKeys []foreignKey) error {
+	generated := make(map[string]struct{}, len(models))
+	var b strings.Builder
+	b.WriteString("// Code generated by gormdb2struct; DO NOT EDIT.\n")
+	b.WriteString("digraph schema {\n")
+	b.WriteString("\trankdir=LR;\n")
+	b.WriteString("\tnode [shape=box];\n")
+	for _, model := range models {
+		generated[model.TableName] = struct{}{}
+		attrs := fmt.Sprintf(`label="%s\n%s"`, dotEscape(model.TableName), dotEscape(model.StructName))
+		if model.View {
+			attrs += ", style=dashed"
+		}
+		fmt.Fprintf(&b, "\t\"%s\" [%s];\n", dotEscape(model.TableName), attrs)
+	}
+	for _, key := range foreignKeys {
+		if _, ok := generated[key.Table]; !ok {
+			continue
+		}
+		if _, ok := generated[key.RefTable]; !ok {
+			continue
+		}
+		fmt.Fprintf(&b, "\t\"%s\" -> \"%s\" [label=\"%s\"];\n", dotEscape(key.Table), dotEscape(key.RefTable), dotEscape(strings.Join(key.Columns, ", ")))
+	}
+	b.WriteString("}\n")
+
+	outFile := filepath.Join(outPath, "schema_gen.dot")
+	if err := os.WriteFile(outFile, []byte(b.String()), 0o644); err != nil {
+		return fmt.Errorf("write ERD %s: %w", outFile, err)
+	}
+	return nil
+}
+
+// dotEscape escapes a value for use inside a double-quoted Graphviz string.
+func dotEscape(value string) string {
+	return strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(value)
+}
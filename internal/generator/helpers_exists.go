@@ -0,0 +1,155 @@
+package generator
+
+import (
+	"go/token"
+	"path/filepath"
+	"strings"
+
+	"gorm.io/gen"
+	"gorm.io/gen/field"
+)
+
+type existsParam struct {
+	Name   string
+	Type   string
+	Column string
+}
+
+type existsCheck struct {
+	Suffix string
+	Params []existsParam
+}
+
+type existsModel struct {
+	StructName string
+	Checks     []existsCheck
+}
+
+// writeExists emits <Model>ExistsByID for single-column primary keys and
+// <Model>ExistsBy<Fields> for every unique index, each a SELECT 1 ... LIMIT 1
+// instead of a COUNT.
+func writeExists(g *gen.Generator, models []helperModel, importPaths []string) error {
+	imports := map[string]struct{}{}
+	data := struct {
+		PackageName string
+		Imports     []string
+		Models      []existsModel
+	}{
+		PackageName: modelsPackageName(g),
+	}
+	for _, model := range models {
+		entry := existsModel{StructName: model.StructName}
+		seen := map[string]struct{}{}
+		addCheck := func(suffix string, fields []gen.Field, names []string) {
+			if _, exists := seen[suffix]; exists {
+				return
+			}
+			check := existsCheck{Suffix: suffix}
+			checkImports := map[string]struct{}{}
+			for i, fld := range fields {
+				goType := strings.TrimLeft(fld.Type, "*")
+				importPath, ok := qualifiedTypeImport(goType, importPaths)
+				if !ok {
+					return
+				}
+				checkImports[importPath] = struct{}{}
+				check.Params = append(check.Params, existsParam{Name: names[i], Type: goType, Column: fld.ColumnName})
+			}
+			for importPath := range checkImports {
+				imports[importPath] = struct{}{}
+			}
+			seen[suffix] = struct{}{}
+			entry.Checks = append(entry.Checks, check)
+		}
+
+		var primaryKeys []gen.Field
+		for _, fld := range model.Fields {
+			if _, isPrimaryKey := fld.GORMTag[field.TagKeyGormPrimaryKey]; isPrimaryKey {
+				primaryKeys = append(primaryKeys, fld)
+			}
+		}
+		if len(primaryKeys) == 1 {
+			addCheck("ID", primaryKeys, []string{"id"})
+		}
+		for _, index := range modelIndexes(model.Fields) {
+			if !index.Unique {
+				continue
+			}
+			var suffix strings.Builder
+			names := make([]string, 0, len(index.Fields))
+			for _, fld := range index.Fields {
+				suffix.WriteString(fld.Name)
+				names = append(names, existsParamName(fld.Name))
+			}
+			addCheck(suffix.String(), index.Fields, names)
+		}
+		if len(entry.Checks) == 0 {
+			continue
+		}
+		data.Models = append(data.Models, entry)
+	}
+	if len(data.Models) == 0 {
+		return nil
+	}
+	data.Imports = helperImports(imports, "gorm.io/gorm", "gorm.io/gorm/clause")
+
+	rendered, err := renderTemplate("exists", existsTemplate, data)
+	if err != nil {
+		return err
+	}
+	return writeFormattedGoFile(filepath.Join(g.ModelPkgPath, "zz_exists.gen.go"), rendered)
+}
+
+// existsParamName turns a field name into a parameter name that cannot clash
+// with a Go keyword or the db parameter.
+func existsParamName(fieldName string) string {
+	name := lowerLeadingWord(fieldName)
+	if token.IsKeyword(name) || name == "db" {
+		name += "Value"
+	}
+	return name
+}
+
+// lowerLeadingWord lower-cases the first word of an exported Go name while
+// keeping initialisms intact: ID -> id, OrgID -> orgID, URLPath -> urlPath.
+func lowerLeadingWord(name string) string {
+	upper := 0
+	for upper < len(name) && name[upper] >= 'A' && name[upper] <= 'Z' {
+		upper++
+	}
+	switch {
+	case upper == 0:
+		return name
+	case upper == len(name):
+		return strings.ToLower(name)
+	case upper > 1:
+		upper--
+	}
+	return strings.ToLower(name[:upper]) + name[upper:]
+}
+
+const existsTemplate = `// Code generated by gormdb2struct; DO NOT EDIT.
+package {{.PackageName}}
+
+import (
+	{{- range .Imports}}
+	{{printf "%q" .}}
+	{{- end}}
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+{{range $model := .Models}}
+{{- range .Checks}}
+// {{$model.StructName}}ExistsBy{{.Suffix}} reports whether a {{$model.StructName}} row matches
+{{- range $i, $param := .Params}}{{if $i}},{{end}} {{$param.Column}}{{end}}.
+func {{$model.StructName}}ExistsBy{{.Suffix}}(db *gorm.DB{{range .Params}}, {{.Name}} {{.Type}}{{end}}) (bool, error) {
+	var found int
+	result := db.Model(&{{$model.StructName}}{}).Select("1").
+	{{- range .Params}}
+		Where(clause.Eq{Column: clause.Column{Table: clause.CurrentTable, Name: {{printf "%q" .Column}}}, Value: {{.Name}}}).
+	{{- end}}
+		Limit(1).Find(&found)
+	return result.RowsAffected > 0, result.Error
+}
+{{end}}
+{{- end}}`
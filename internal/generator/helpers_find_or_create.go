@@ -0,0 +1,110 @@
+package generator
+
+import (
+	"path/filepath"
+
+	"gorm.io/gen"
+)
+
+type findOrCreateKey struct {
+	FieldName  string
+	ColumnName string
+}
+
+type findOrCreateModel struct {
+	StructName string
+	IndexName  string
+	Keys       []findOrCreateKey
+}
+
+// writeFindOrCreate emits FindOrCreate<Model> for every table with a unique
+// index. The lookup uses the first unique index by name and the insert ignores
+// conflicts, so a concurrent insert of the same key resolves to a lookup.
+func writeFindOrCreate(g *gen.Generator, models []helperModel) error {
+	data := struct {
+		PackageName string
+		Models      []findOrCreateModel
+	}{
+		PackageName: modelsPackageName(g),
+	}
+	for _, model := range models {
+		if model.View {
+			continue
+		}
+		index, ok := firstUniqueIndex(model.Fields)
+		if !ok {
+			continue
+		}
+		entry := findOrCreateModel{StructName: model.StructName, IndexName: index.Name}
+		for _, fld := range index.Fields {
+			entry.Keys = append(entry.Keys, findOrCreateKey{FieldName: fld.Name, ColumnName: fld.ColumnName})
+		}
+		data.Models = append(data.Models, entry)
+	}
+	if len(data.Models) == 0 {
+		return nil
+	}
+
+	rendered, err := renderTemplate("find_or_create", findOrCreateTemplate, data)
+	if err != nil {
+		return err
+	}
+	return writeFormattedGoFile(filepath.Join(g.ModelPkgPath, "zz_find_or_create.gen.go"), rendered)
+}
+
+func firstUniqueIndex(fields []gen.Field) (modelIndex, bool) {
+	for _, index := range modelIndexes(fields) {
+		if index.Unique {
+			return index, true
+		}
+	}
+	return modelIndex{}, false
+}
+
+const findOrCreateTemplate = `// Code generated by gormdb2struct; DO NOT EDIT.
+package {{.PackageName}}
+
+import (
+	"errors"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+{{range .Models}}
+// FindOrCreate{{.StructName}} looks up a {{.StructName}} by the {{.IndexName}} columns
+// of where and creates it from defaults when missing. The bool reports whether
+// a row was created.
+func FindOrCreate{{.StructName}}(db *gorm.DB, where {{.StructName}}, defaults {{.StructName}}) ({{.StructName}}, bool, error) {
+	conds := map[string]any{
+		{{- range .Keys}}
+		{{printf "%q" .ColumnName}}: where.{{.FieldName}},
+		{{- end}}
+	}
+
+	var found {{.StructName}}
+	err := db.Where(conds).Take(&found).Error
+	if err == nil {
+		return found, false, nil
+	}
+	if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return {{.StructName}}{}, false, err
+	}
+
+	created := defaults
+	{{- range .Keys}}
+	created.{{.FieldName}} = where.{{.FieldName}}
+	{{- end}}
+	result := db.Clauses(clause.OnConflict{DoNothing: true}).Create(&created)
+	if result.Error != nil {
+		return {{.StructName}}{}, false, result.Error
+	}
+	if result.RowsAffected > 0 {
+		return created, true, nil
+	}
+
+	if err := db.Where(conds).Take(&found).Error; err != nil {
+		return {{.StructName}}{}, false, err
+	}
+	return found, false, nil
+}
+{{end}}`
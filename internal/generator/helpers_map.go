@@ -0,0 +1,190 @@
+package generator
+
+import (
+	"path/filepath"
+
+	"gorm.io/gen"
+)
+
+type mapColumn struct {
+	FieldName  string
+	ColumnName string
+}
+
+type mapModel struct {
+	StructName string
+	Columns    []mapColumn
+}
+
+// writeMapConversion emits (m <Model>) ToMap() keyed by column name and
+// <Model>FromMap, which coerces loosely typed values (JSON numbers, RFC 3339
+// strings, decoded JSON documents) into the field types through a shared
+// reflection helper, so the generated code never spells out field types.
+func writeMapConversion(g *gen.Generator, models []helperModel) error {
+	data := struct {
+		PackageName string
+		Models      []mapModel
+	}{
+		PackageName: modelsPackageName(g),
+	}
+	for _, model := range models {
+		entry := mapModel{StructName: model.StructName}
+		for _, fld := range model.Fields {
+			if fld.ColumnName == "" {
+				continue
+			}
+			entry.Columns = append(entry.Columns, mapColumn{FieldName: fld.Name, ColumnName: fld.ColumnName})
+		}
+		if len(entry.Columns) > 0 {
+			data.Models = append(data.Models, entry)
+		}
+	}
+	if len(data.Models) == 0 {
+		return nil
+	}
+
+	rendered, err := renderTemplate("map_conversion", mapConversionTemplate, data)
+	if err != nil {
+		return err
+	}
+	return writeFormattedGoFile(filepath.Join(g.ModelPkgPath, "zz_map.gen.go"), rendered)
+}
+
+const mapConversionTemplate = `// Code generated by gormdb2struct; DO NOT EDIT.
+package {{.PackageName}}
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+	"time"
+)
+
+// ErrUnknownMapColumn is returned by the <Model>FromMap functions for keys that are not columns of the model.
+var ErrUnknownMapColumn = errors.New("unknown column")
+{{range .Models}}
+// ToMap returns the column values of m keyed by column name, for example for db.Updates.
+func (m {{.StructName}}) ToMap() map[string]any {
+	return map[string]any{
+	{{- range .Columns}}
+		{{printf "%q" .ColumnName}}: m.{{.FieldName}},
+	{{- end}}
+	}
+}
+
+// {{.StructName}}FromMap builds a {{.StructName}} from column-keyed values, coercing JSON-decoded
+// numbers, strings, and documents into the field types.
+func {{.StructName}}FromMap(values map[string]any) ({{.StructName}}, error) {
+	var m {{.StructName}}
+	for _, column := range sortedMapColumns(values) {
+		var err error
+		switch column {
+		{{- range .Columns}}
+		case {{printf "%q" .ColumnName}}:
+			err = assignMapValue(&m.{{.FieldName}}, values[column])
+		{{- end}}
+		default:
+			err = ErrUnknownMapColumn
+		}
+		if err != nil {
+			return {{.StructName}}{}, fmt.Errorf("{{.StructName}} column %q: %w", column, err)
+		}
+	}
+	return m, nil
+}
+{{end}}
+func sortedMapColumns(values map[string]any) []string {
+	columns := make([]string, 0, len(values))
+	for column := range values {
+		columns = append(columns, column)
+	}
+	sort.Strings(columns)
+	return columns
+}
+
+func assignMapValue(dst any, value any) error {
+	return setMapValue(reflect.ValueOf(dst).Elem(), value)
+}
+
+func setMapValue(target reflect.Value, value any) error {
+	if value == nil {
+		target.Set(reflect.Zero(target.Type()))
+		return nil
+	}
+	src := reflect.ValueOf(value)
+	if src.Type().AssignableTo(target.Type()) {
+		target.Set(src)
+		return nil
+	}
+	if src.Kind() == reflect.Pointer {
+		if src.IsNil() {
+			target.Set(reflect.Zero(target.Type()))
+			return nil
+		}
+		return setMapValue(target, src.Elem().Interface())
+	}
+	if target.Kind() == reflect.Pointer {
+		elem := reflect.New(target.Type().Elem())
+		if err := setMapValue(elem.Elem(), value); err != nil {
+			return err
+		}
+		target.Set(elem)
+		return nil
+	}
+	if converted, ok := convertMapScalar(src, target.Type()); ok {
+		target.Set(converted)
+		return nil
+	}
+	if s, ok := value.(string); ok && target.Type() == reflect.TypeOf(time.Time{}) {
+		parsed, err := time.Parse(time.RFC3339Nano, s)
+		if err != nil {
+			return err
+		}
+		target.Set(reflect.ValueOf(parsed))
+		return nil
+	}
+	scanErr := fmt.Errorf("cannot assign %T to %s", value, target.Type())
+	if scanner, ok := target.Addr().Interface().(sql.Scanner); ok {
+		if scanErr = scanner.Scan(value); scanErr == nil {
+			return nil
+		}
+	}
+	if unmarshaler, ok := target.Addr().Interface().(json.Unmarshaler); ok {
+		raw, err := json.Marshal(value)
+		if err != nil {
+			return err
+		}
+		return unmarshaler.UnmarshalJSON(raw)
+	}
+	return scanErr
+}
+
+// convertMapScalar converts between numeric kinds when the value survives the
+// round trip (3.0 becomes int64(3), 3.5 and overflows are rejected), between
+// string kinds, and between strings and byte slices. Everything else is left
+// to Scan and UnmarshalJSON.
+func convertMapScalar(src reflect.Value, targetType reflect.Type) (reflect.Value, bool) {
+	switch {
+	case isMapNumberKind(src.Kind()) && isMapNumberKind(targetType.Kind()):
+		converted := src.Convert(targetType)
+		if !converted.Convert(src.Type()).Equal(src) {
+			return reflect.Value{}, false
+		}
+		return converted, true
+	case src.Kind() == reflect.String && targetType.Kind() == reflect.String,
+		src.Kind() == reflect.Bool && targetType.Kind() == reflect.Bool:
+		return src.Convert(targetType), true
+	case src.Kind() == reflect.String && targetType.Kind() == reflect.Slice && targetType.Elem().Kind() == reflect.Uint8,
+		src.Kind() == reflect.Slice && src.Type().Elem().Kind() == reflect.Uint8 && targetType.Kind() == reflect.String:
+		return src.Convert(targetType), true
+	}
+	return reflect.Value{}, false
+}
+
+func isMapNumberKind(kind reflect.Kind) bool {
+	return kind >= reflect.Int && kind <= reflect.Float64
+}
+`
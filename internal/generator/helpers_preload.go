@@ -0,0 +1,122 @@
+package generator
+
+import (
+	"path/filepath"
+	"strings"
+
+	"gorm.io/gen"
+)
+
+type preloadRelation struct {
+	Name string
+	// PathType is the <Target>Path type of a relation to a generated model,
+	// or RelationPath when the target is not one.
+	PathType string
+}
+
+type preloadTemplateModel struct {
+	StructName string
+	// Target reports whether another model has a relation to this one, which
+	// is when it needs a <Model>Path type.
+	Target    bool
+	Relations []preloadRelation
+}
+
+// writePreloadConstants emits one <Model>Rels value per model that declares
+// relation fields so Preload paths are checked by the compiler instead of
+// failing silently after a rename. Each relation to a generated model is typed
+// as that model's <Model>Path, whose methods extend it with the target's own
+// relations, so a nested path cannot name a relation the target lacks.
+func writePreloadConstants(g *gen.Generator, models []helperModel) error {
+	data := struct {
+		PackageName string
+		Models      []preloadTemplateModel
+	}{
+		PackageName: modelsPackageName(g),
+	}
+
+	byName := make(map[string]*preloadTemplateModel, len(models))
+	entries := make([]*preloadTemplateModel, 0, len(models))
+	for _, model := range models {
+		entry := &preloadTemplateModel{StructName: model.StructName}
+		byName[model.StructName] = entry
+		entries = append(entries, entry)
+	}
+	for i, model := range models {
+		for _, relation := range model.Relations {
+			pathType := "RelationPath"
+			if target, ok := byName[relationTargetStructName(relation)]; ok {
+				target.Target = true
+				pathType = target.StructName + "Path"
+			}
+			entries[i].Relations = append(entries[i].Relations, preloadRelation{Name: relation.Name, PathType: pathType})
+		}
+	}
+	for _, entry := range entries {
+		if entry.Target || len(entry.Relations) > 0 {
+			data.Models = append(data.Models, *entry)
+		}
+	}
+
+	rendered, err := renderTemplate("preload_constants", preloadConstantsTemplate, data)
+	if err != nil {
+		return err
+	}
+	return writeFormattedGoFile(filepath.Join(g.ModelPkgPath, "zz_preload.gen.go"), rendered)
+}
+
+// relationTargetStructName returns the model struct a relation field points
+// to, such as "Order" for a "[]*Order" or "*models.Order" field.
+func relationTargetStructName(relation gen.Field) string {
+	target := strings.TrimLeft(relation.Type, "*[]")
+	if index := strings.LastIndex(target, "."); index >= 0 {
+		target = target[index+1:]
+	}
+	return target
+}
+
+const preloadConstantsTemplate = `// Code generated by gormdb2struct; DO NOT EDIT.
+package {{.PackageName}}
+
+// RelationPath is a GORM Preload path to a type that is not a generated
+// model, so it cannot be extended.
+type RelationPath string
+
+// String returns the dotted path accepted by gorm.DB.Preload.
+func (p RelationPath) String() string {
+	return string(p)
+}
+{{range .Models}}
+{{- if .Target}}
+{{- $path := printf "%sPath" .StructName}}
+// {{$path}} is a Preload path that ends at {{.StructName}}. Its methods extend
+// it with the relations declared on {{.StructName}}.
+type {{$path}} string
+
+// String returns the dotted path accepted by gorm.DB.Preload.
+func (p {{$path}}) String() string {
+	return string(p)
+}
+{{- range .Relations}}
+{{- if ne .Name "String"}}
+
+// {{.Name}} extends the path with the {{.Name}} relation.
+func (p {{$path}}) {{.Name}}() {{.PathType}} {
+	return {{.PathType}}(string(p) + {{printf "%q" (printf ".%s" .Name)}})
+}
+{{- end}}
+{{- end}}
+{{end}}
+{{- if .Relations}}
+// {{.StructName}}Rels lists the relations declared on {{.StructName}}.
+var {{.StructName}}Rels = struct {
+{{- range .Relations}}
+	{{.Name}} {{.PathType}}
+{{- end}}
+}{
+{{- range .Relations}}
+	{{.Name}}: {{printf "%q" .Name}},
+{{- end}}
+}
+{{end}}
+{{- end}}`
@@ -0,0 +1,164 @@
+package generator
+
+import (
+	"path/filepath"
+
+	"gorm.io/gen"
+	"gorm.io/gen/field"
+)
+
+type schemaAssertionColumn struct {
+	Name string
+	Type string
+}
+
+type schemaAssertionModel struct {
+	StructName       string
+	TableName        string
+	MaterializedView bool
+	Columns          []schemaAssertionColumn
+}
+
+// writeSchemaAssertion embeds the column names and database types captured
+// during generation and emits AssertSchema, which compares them against the
+// live database through GORM's Migrator, or through pg_attribute for
+// PostgreSQL materialized views, which the Migrator does not list.
+func writeSchemaAssertion(g *gen.Generator, models []helperModel) error {
+	data := struct {
+		PackageName          string
+		HasMaterializedViews bool
+		Models               []schemaAssertionModel
+	}{
+		PackageName: modelsPackageName(g),
+	}
+	for _, model := range models {
+		entry := schemaAssertionModel{StructName: model.StructName, TableName: model.TableName, MaterializedView: model.MaterializedView}
+		data.HasMaterializedViews = data.HasMaterializedViews || model.MaterializedView
+		for _, fld := range model.Fields {
+			if fld.ColumnName == "" {
+				continue
+			}
+			entry.Columns = append(entry.Columns, schemaAssertionColumn{
+				Name: fld.ColumnName,
+				Type: firstGormTagValue(fld, field.TagKeyGormType),
+			})
+		}
+		data.Models = append(data.Models, entry)
+	}
+
+	rendered, err := renderTemplate("schema_assertion", schemaAssertionTemplate, data)
+	if err != nil {
+		return err
+	}
+	return writeFormattedGoFile(filepath.Join(g.ModelPkgPath, "zz_schema_assert.gen.go"), rendered)
+}
+
+const schemaAssertionTemplate = `// Code generated by gormdb2struct; DO NOT EDIT.
+package {{.PackageName}}
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"gorm.io/gorm"
+)
+
+type schemaAssertionColumn struct {
+	Name string
+	Type string
+}
+
+// expectedSchema is the column layout each model was generated from.
+var expectedSchema = []struct {
+	Model            string
+	Table            string
+	MaterializedView bool
+	Columns          []schemaAssertionColumn
+}{
+{{- range .Models}}
+	{Model: {{printf "%q" .StructName}}, Table: {{printf "%q" .TableName}},{{if .MaterializedView}} MaterializedView: true,{{end}} Columns: []schemaAssertionColumn{
+	{{- range .Columns}}
+		{Name: {{printf "%q" .Name}}, Type: {{printf "%q" .Type}}},
+	{{- end}}
+	}},
+{{- end}}
+}
+
+// AssertSchema compares every generated model against the live database and
+// returns an error listing each missing table, missing column, or column whose
+// type differs from the one the models were generated from. Extra columns in
+// the database are allowed. Call it after connecting so a binary refuses to
+// serve against an incompatible schema.
+func AssertSchema(db *gorm.DB) error {
+	var errs []error
+	for _, model := range expectedSchema {
+		actual, err := schemaColumnTypes(db, model.Table, model.MaterializedView)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s: read columns of %q: %w", model.Model, model.Table, err))
+			continue
+		}
+		if len(actual) == 0 {
+			errs = append(errs, fmt.Errorf("%s: table %q not found", model.Model, model.Table))
+			continue
+		}
+
+		for _, column := range model.Columns {
+			typeName, ok := actual[column.Name]
+			if !ok {
+				errs = append(errs, fmt.Errorf("%s: column %q.%q not found", model.Model, model.Table, column.Name))
+				continue
+			}
+			if column.Type != "" && typeName != "" && !strings.EqualFold(column.Type, typeName) {
+				errs = append(errs, fmt.Errorf("%s: column %q.%q has type %q, generated from %q", model.Model, model.Table, column.Name, typeName, column.Type))
+			}
+		}
+	}
+	if len(errs) > 0 {
+		return fmt.Errorf("database schema does not match generated models: %w", errors.Join(errs...))
+	}
+	return nil
+}
+
+// schemaColumnTypes maps each column of table to its database type.
+func schemaColumnTypes(db *gorm.DB, table string, materializedView bool) (map[string]string, error) {
+{{- if .HasMaterializedViews}}
+	if materializedView {
+		// The Migrator reads information_schema.columns, which does not list
+		// materialized views, so their columns come from pg_attribute.
+		var rows []struct {
+			Name string
+			Type string
+		}
+		if err := db.Raw(` + "`" + `
+			SELECT a.attname AS name, format_type(a.atttypid, a.atttypmod) AS type
+			FROM pg_attribute a
+			JOIN pg_class c ON c.oid = a.attrelid
+			JOIN pg_namespace n ON n.oid = c.relnamespace
+			WHERE n.nspname = current_schema()
+			  AND c.relname = ?
+			  AND c.relkind = 'm'
+			  AND a.attnum > 0
+			  AND NOT a.attisdropped
+		` + "`" + `, table).Scan(&rows).Error; err != nil {
+			return nil, err
+		}
+		actual := make(map[string]string, len(rows))
+		for _, row := range rows {
+			actual[row.Name] = row.Type
+		}
+		return actual, nil
+	}
+{{- end}}
+	columnTypes, err := db.Migrator().ColumnTypes(table)
+	if err != nil {
+		return nil, err
+	}
+	actual := make(map[string]string, len(columnTypes))
+	for _, columnType := range columnTypes {
+		typeName, _ := columnType.ColumnType()
+		actual[columnType.Name()] = typeName
+	}
+	return actual, nil
+}
+`
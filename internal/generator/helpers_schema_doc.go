@@ -0,0 +1,134 @@
+package generator
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"gorm.io/gen"
+	"gorm.io/gen/field"
+)
+
+// writeSchemaDoc emits SCHEMA.md, a readable reference of every generated table
+// and view built from the same field metadata gen renders into the models.
+func writeSchemaDoc(outPath string, models []helperModel) error {
+	sorted := append([]helperModel(nil), models...)
+	sort.SliceStable(sorted, func(i, j int) bool { return sorted[i].TableName < sorted[j].TableName })
+
+	var b strings.Builder
+	b.WriteString("<!-- Code generated by gormdb2struct; DO NOT EDIT. -->\n")
+	b.WriteString("# Database Schema\n")
+
+	for _, section := range []struct {
+		Title string
+		View  bool
+	}{
+		{Title: "Tables", View: false},
+		{Title: "Views", View: true},
+	} {
+		first := true
+		for _, model := range sorted {
+			if model.View != section.View {
+				continue
+			}
+			if first {
+				fmt.Fprintf(&b, "\n## %s\n", section.Title)
+				first = false
+			}
+			writeSchemaDocModel(&b, model)
+		}
+	}
+
+	outFile := filepath.Join(outPath, "SCHEMA.md")
+	if err := os.WriteFile(outFile, []byte(b.String()), 0o644); err != nil {
+		return fmt.Errorf("write schema doc %s: %w", outFile, err)
+	}
+	return nil
+}
+
+func writeSchemaDocModel(b *strings.Builder, model helperModel) {
+	kind := "table"
+	if model.View {
+		kind = "view"
+	}
+	fmt.Fprintf(b, "\n### `%s`\n\n", model.TableName)
+	fmt.Fprintf(b, "Go model: `%s` (%s)\n\n", model.StructName, kind)
+
+	b.WriteString("| Column | Type | Go Type | Nullable | Default | Comment |\n")
+	b.WriteString("| --- | --- | --- | --- | --- | --- |\n")
+	var primaryKey []string
+	for _, fld := range model.Fields {
+		_, notNull := fld.GORMTag[field.TagKeyGormNotNull]
+		if _, isPrimaryKey := fld.GORMTag[field.TagKeyGormPrimaryKey]; isPrimaryKey {
+			primaryKey = append(primaryKey, fld.ColumnName)
+			notNull = true
+		}
+		nullable := "yes"
+		if notNull {
+			nullable = "no"
+		}
+		fmt.Fprintf(b, "| `%s` | %s | `%s` | %s | %s | %s |\n",
+			fld.ColumnName,
+			schemaDocCode(firstGormTagValue(fld, field.TagKeyGormType)),
+			fld.Type,
+			nullable,
+			schemaDocCode(firstGormTagValue(fld, field.TagKeyGormDefault)),
+			schemaDocCell(firstGormTagValue(fld, field.TagKeyGormComment)),
+		)
+	}
+
+	if len(primaryKey) > 0 {
+		fmt.Fprintf(b, "\nPrimary key: %s\n", schemaDocColumnList(primaryKey))
+	}
+
+	if indexes := modelIndexes(model.Fields); len(indexes) > 0 {
+		b.WriteString("\nIndexes:\n")
+		for _, index := range indexes {
+			unique := ""
+			if index.Unique {
+				unique = " (unique)"
+			}
+			fmt.Fprintf(b, "- `%s`%s: %s\n", index.Name, unique, schemaDocColumnList(index.Columns))
+		}
+	}
+
+	if len(model.Relations) > 0 {
+		b.WriteString("\nRelations:\n")
+		for _, relation := range model.Relations {
+			fmt.Fprintf(b, "- `%s` %s `%s`\n",
+				relation.Name,
+				strings.ReplaceAll(string(relation.Relation.Relationship()), "_", " "),
+				relation.Relation.Type(),
+			)
+		}
+	}
+}
+
+func firstGormTagValue(fld gen.Field, key string) string {
+	values := fld.GORMTag[key]
+	if len(values) == 0 {
+		return ""
+	}
+	return values[0]
+}
+
+func schemaDocColumnList(columns []string) string {
+	quoted := make([]string, 0, len(columns))
+	for _, column := range columns {
+		quoted = append(quoted, "`"+column+"`")
+	}
+	return strings.Join(quoted, ", ")
+}
+
+func schemaDocCode(value string) string {
+	if value == "" {
+		return ""
+	}
+	return "`" + schemaDocCell(value) + "`"
+}
+
+func schemaDocCell(value string) string {
+	return strings.ReplaceAll(strings.ReplaceAll(value, "|", `\|`), "\n", " ")
+}
@@ -0,0 +1,104 @@
+package generator
+
+import (
+	"path/filepath"
+	"strings"
+
+	"gorm.io/gen"
+	"gorm.io/gen/field"
+)
+
+type scopeModel struct {
+	StructName       string
+	ActiveColumn     string
+	DeletedAtColumn  string
+	PrimaryKeyColumn string
+	PrimaryKeyType   string
+}
+
+// writeScopes emits GORM scopes derived from column conventions: <Model>Active
+// for a boolean active/is_active column, <Model>NotDeleted for soft-delete
+// tables, and <Model>ByID for single-column primary keys.
+func writeScopes(g *gen.Generator, models []helperModel, importPaths []string) error {
+	imports := map[string]struct{}{}
+	data := struct {
+		PackageName string
+		Imports     []string
+		Models      []scopeModel
+	}{
+		PackageName: modelsPackageName(g),
+	}
+	for _, model := range models {
+		entry := scopeModel{StructName: model.StructName}
+		var primaryKeys []gen.Field
+		for _, fld := range model.Fields {
+			goType := strings.TrimLeft(fld.Type, "*")
+			switch {
+			case goType == "bool" && (fld.ColumnName == "active" || fld.ColumnName == "is_active"):
+				if entry.ActiveColumn == "" {
+					entry.ActiveColumn = fld.ColumnName
+				}
+			case goType == "gorm.DeletedAt":
+				entry.DeletedAtColumn = fld.ColumnName
+			}
+			if _, isPrimaryKey := fld.GORMTag[field.TagKeyGormPrimaryKey]; isPrimaryKey {
+				primaryKeys = append(primaryKeys, fld)
+			}
+		}
+		if len(primaryKeys) == 1 {
+			keyType := strings.TrimLeft(primaryKeys[0].Type, "*")
+			if importPath, ok := qualifiedTypeImport(keyType, importPaths); ok {
+				entry.PrimaryKeyColumn = primaryKeys[0].ColumnName
+				entry.PrimaryKeyType = keyType
+				imports[importPath] = struct{}{}
+			}
+		}
+		if entry.ActiveColumn == "" && entry.DeletedAtColumn == "" && entry.PrimaryKeyColumn == "" {
+			continue
+		}
+		data.Models = append(data.Models, entry)
+	}
+	if len(data.Models) == 0 {
+		return nil
+	}
+	data.Imports = helperImports(imports, "gorm.io/gorm", "gorm.io/gorm/clause")
+
+	rendered, err := renderTemplate("scopes", scopesTemplate, data)
+	if err != nil {
+		return err
+	}
+	return writeFormattedGoFile(filepath.Join(g.ModelPkgPath, "zz_scopes.gen.go"), rendered)
+}
+
+const scopesTemplate = `// Code generated by gormdb2struct; DO NOT EDIT.
+package {{.PackageName}}
+
+import (
+	{{- range .Imports}}
+	{{printf "%q" .}}
+	{{- end}}
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+{{range .Models}}
+{{- if .ActiveColumn}}
+// {{.StructName}}Active keeps rows whose {{.ActiveColumn}} column is true.
+func {{.StructName}}Active(db *gorm.DB) *gorm.DB {
+	return db.Where(clause.Eq{Column: clause.Column{Table: clause.CurrentTable, Name: {{printf "%q" .ActiveColumn}}}, Value: true})
+}
+{{end}}
+{{- if .DeletedAtColumn}}
+// {{.StructName}}NotDeleted keeps rows that are not soft-deleted, even on an Unscoped query.
+func {{.StructName}}NotDeleted(db *gorm.DB) *gorm.DB {
+	return db.Where(clause.Eq{Column: clause.Column{Table: clause.CurrentTable, Name: {{printf "%q" .DeletedAtColumn}}}, Value: nil})
+}
+{{end}}
+{{- if .PrimaryKeyColumn}}
+// {{.StructName}}ByID matches the row with the given {{.PrimaryKeyColumn}}.
+func {{.StructName}}ByID(id {{.PrimaryKeyType}}) func(*gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		return db.Where(clause.Eq{Column: clause.Column{Table: clause.CurrentTable, Name: {{printf "%q" .PrimaryKeyColumn}}}, Value: id})
+	}
+}
+{{end}}
+{{- end}}`
@@ -0,0 +1,100 @@
+package generator
+
+import (
+	"path/filepath"
+
+	"gorm.io/gen"
+)
+
+type sensitiveStringerField struct {
+	Name     string
+	Redacted bool
+}
+
+type sensitiveStringerModel struct {
+	StructName string
+	Fields     []sensitiveStringerField
+}
+
+// writeSensitiveStringers emits a String method for every model with
+// SensitiveColumns so %v and structured loggers print a redacted value.
+func writeSensitiveStringers(g *gen.Generator, models []helperModel, sensitiveColumns map[string][]string) error {
+	data := struct {
+		PackageName string
+		Models      []sensitiveStringerModel
+	}{
+		PackageName: modelsPackageName(g),
+	}
+	for _, model := range models {
+		columns := sensitiveColumns[model.TableName]
+		if len(columns) == 0 {
+			continue
+		}
+		entry := sensitiveStringerModel{StructName: model.StructName}
+		for _, fld := range model.Fields {
+			entry.Fields = append(entry.Fields, sensitiveStringerField{
+				Name:     fld.Name,
+				Redacted: isSensitiveField(fld, columns),
+			})
+		}
+		data.Models = append(data.Models, entry)
+	}
+	if len(data.Models) == 0 {
+		return nil
+	}
+
+	rendered, err := renderTemplate("sensitive_stringers", sensitiveStringersTemplate, data)
+	if err != nil {
+		return err
+	}
+	return writeFormattedGoFile(filepath.Join(g.ModelPkgPath, "zz_sensitive.gen.go"), rendered)
+}
+
+func isSensitiveField(fld gen.Field, columns []string) bool {
+	for _, column := range columns {
+		if fld.ColumnName == column || fld.Name == column {
+			return true
+		}
+	}
+	return false
+}
+
+const sensitiveStringersTemplate = `// Code generated by gormdb2struct; DO NOT EDIT.
+package {{.PackageName}}
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+{{range .Models}}
+// String prints {{.StructName}} with its sensitive columns redacted.
+func (m {{.StructName}}) String() string {
+	var b strings.Builder
+	b.WriteString("{{.StructName}}{")
+	{{- range $i, $f := .Fields}}
+	{{- if $i}}
+	b.WriteString(" ")
+	{{- end}}
+	{{- if $f.Redacted}}
+	b.WriteString("{{$f.Name}}:[REDACTED]")
+	{{- else}}
+	fmt.Fprintf(&b, "{{$f.Name}}:%v", redactedStringValue(m.{{$f.Name}}))
+	{{- end}}
+	{{- end}}
+	b.WriteString("}")
+	return b.String()
+}
+{{end}}
+// redactedStringValue dereferences pointers so String prints values, not addresses.
+func redactedStringValue(v any) any {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Pointer {
+		return v
+	}
+	if rv.IsNil() {
+		return nil
+	}
+	return rv.Elem().Interface()
+}
+`
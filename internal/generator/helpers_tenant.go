@@ -0,0 +1,191 @@
+package generator
+
+import (
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"gorm.io/gen"
+)
+
+// writeTenantScope emits ScopeByTenant for the tables that have the configured
+// tenant column and, with enforce, RegisterTenantEnforcement which installs
+// GORM callbacks that scope queries, updates, and deletes on those tables to
+// the tenant carried by the statement context and stamp it on create.
+func writeTenantScope(g *gen.Generator, models []helperModel, column string, enforce bool, importPaths []string) error {
+	data := struct {
+		PackageName string
+		Imports     []string
+		Column      string
+		TenantType  string
+		Enforce     bool
+		Tables      []string
+	}{
+		PackageName: modelsPackageName(g),
+		Column:      column,
+		Enforce:     enforce,
+	}
+	typesByTable := map[string]string{}
+	for _, model := range models {
+		fld := findFieldByColumn(model.Fields, column)
+		if fld == nil {
+			continue
+		}
+		goType := strings.TrimLeft(fld.Type, "*")
+		typesByTable[model.TableName] = goType
+		data.Tables = append(data.Tables, model.TableName)
+		if data.TenantType == "" {
+			data.TenantType = goType
+		}
+	}
+	if len(data.Tables) == 0 {
+		return nil
+	}
+	sort.Strings(data.Tables)
+	for _, table := range data.Tables {
+		if typesByTable[table] != data.TenantType {
+			return fmt.Errorf("TenantColumn %q has type %s on %q but %s on %q", column, typesByTable[table], table, data.TenantType, data.Tables[0])
+		}
+	}
+	importPath, ok := qualifiedTypeImport(data.TenantType, importPaths)
+	if !ok {
+		return fmt.Errorf("TenantColumn %q: cannot resolve the package of type %s", column, data.TenantType)
+	}
+	fixed := []string{"gorm.io/gorm", "gorm.io/gorm/clause"}
+	if enforce {
+		fixed = append(fixed, "context", "errors", "reflect", "gorm.io/gorm/schema")
+	}
+	data.Imports = helperImports(map[string]struct{}{importPath: {}}, fixed...)
+
+	rendered, err := renderTemplate("tenant_scope", tenantScopeTemplate, data)
+	if err != nil {
+		return err
+	}
+	return writeFormattedGoFile(filepath.Join(g.ModelPkgPath, "zz_tenant.gen.go"), rendered)
+}
+
+const tenantScopeTemplate = `// Code generated by gormdb2struct; DO NOT EDIT.
+package {{.PackageName}}
+
+import (
+	{{- if .Enforce}}
+	"context"
+	"errors"
+	"reflect"
+	{{- end}}
+	{{- range .Imports}}
+	{{printf "%q" .}}
+	{{- end}}
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	{{- if .Enforce}}
+	"gorm.io/gorm/schema"
+	{{- end}}
+)
+
+// TenantTables lists the tables that have the {{.Column}} tenant column.
+var TenantTables = map[string]struct{}{
+{{- range .Tables}}
+	{{printf "%q" .}}: {},
+{{- end}}
+}
+
+// ScopeByTenant keeps rows whose {{.Column}} column equals tenantID.
+func ScopeByTenant(tenantID {{.TenantType}}) func(*gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		return db.Where(clause.Eq{Column: clause.Column{Table: clause.CurrentTable, Name: {{printf "%q" .Column}}}, Value: tenantID})
+	}
+}
+{{- if .Enforce}}
+
+// ErrMissingTenant is reported for statements on a tenant table whose context carries no tenant.
+var ErrMissingTenant = errors.New("tenant scope missing: pass a context from WithTenant")
+
+// ErrTenantMismatch is reported when a created row already names a different tenant.
+var ErrTenantMismatch = errors.New("row belongs to a different tenant than the context")
+
+type tenantContextKey struct{}
+
+// WithTenant returns a context that scopes statements on tenant tables to tenantID.
+func WithTenant(ctx context.Context, tenantID {{.TenantType}}) context.Context {
+	return context.WithValue(ctx, tenantContextKey{}, tenantID)
+}
+
+// TenantFromContext returns the tenant set by WithTenant.
+func TenantFromContext(ctx context.Context) ({{.TenantType}}, bool) {
+	tenantID, ok := ctx.Value(tenantContextKey{}).({{.TenantType}})
+	return tenantID, ok
+}
+
+// RegisterTenantEnforcement installs callbacks so every query, update, and delete on a
+// tenant table is filtered by the context tenant, and every create stamps it. Statements
+// without a tenant in their context fail with ErrMissingTenant. Raw SQL is not covered.
+func RegisterTenantEnforcement(db *gorm.DB) error {
+	if err := db.Callback().Query().Before("gorm:query").Register("gormdb2struct:tenant_query", tenantScopeCallback); err != nil {
+		return err
+	}
+	if err := db.Callback().Update().Before("gorm:update").Register("gormdb2struct:tenant_update", tenantScopeCallback); err != nil {
+		return err
+	}
+	if err := db.Callback().Delete().Before("gorm:delete").Register("gormdb2struct:tenant_delete", tenantScopeCallback); err != nil {
+		return err
+	}
+	return db.Callback().Create().Before("gorm:create").Register("gormdb2struct:tenant_create", tenantCreateCallback)
+}
+
+func statementTenant(db *gorm.DB) ({{.TenantType}}, bool) {
+	var tenantID {{.TenantType}}
+	if _, ok := TenantTables[db.Statement.Table]; !ok {
+		return tenantID, false
+	}
+	tenantID, ok := TenantFromContext(db.Statement.Context)
+	if !ok {
+		_ = db.AddError(ErrMissingTenant)
+	}
+	return tenantID, ok
+}
+
+func tenantScopeCallback(db *gorm.DB) {
+	tenantID, ok := statementTenant(db)
+	if !ok {
+		return
+	}
+	db.Statement.AddClause(clause.Where{Exprs: []clause.Expression{
+		clause.Eq{Column: clause.Column{Table: clause.CurrentTable, Name: {{printf "%q" .Column}}}, Value: tenantID},
+	}})
+}
+
+func tenantCreateCallback(db *gorm.DB) {
+	tenantID, ok := statementTenant(db)
+	if !ok || db.Statement.Schema == nil {
+		return
+	}
+	tenantField := db.Statement.Schema.LookUpField({{printf "%q" .Column}})
+	if tenantField == nil {
+		return
+	}
+	switch db.Statement.ReflectValue.Kind() {
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < db.Statement.ReflectValue.Len(); i++ {
+			stampTenant(db, tenantField, db.Statement.ReflectValue.Index(i), tenantID)
+		}
+	case reflect.Struct:
+		stampTenant(db, tenantField, db.Statement.ReflectValue, tenantID)
+	}
+}
+
+func stampTenant(db *gorm.DB, tenantField *schema.Field, row reflect.Value, tenantID {{.TenantType}}) {
+	row = reflect.Indirect(row)
+	if current, isZero := tenantField.ValueOf(db.Statement.Context, row); !isZero {
+		if reflect.Indirect(reflect.ValueOf(current)).Interface() != any(tenantID) {
+			_ = db.AddError(ErrTenantMismatch)
+		}
+		return
+	}
+	if err := tenantField.Set(db.Statement.Context, row, tenantID); err != nil {
+		_ = db.AddError(err)
+	}
+}
+{{- end}}
+`
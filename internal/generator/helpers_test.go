@@ -0,0 +1,360 @@
+package generator
+
+import (
+	"bytes"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"gorm.io/gen"
+	"gorm.io/gen/field"
+)
+
+func TestWritePreloadConstantsEmitsRelationPaths(t *testing.T) {
+	t.Parallel()
+
+	g := newTestGenerator(t)
+	orders := newTestField("Orders", "", "[]*Order", nil)
+	orders.Relation = field.NewRelationWithType(field.HasMany, "Orders", "models.Order")
+	items := newTestField("Items", "", "[]*Item", nil)
+	items.Relation = field.NewRelationWithType(field.HasMany, "Items", "models.Item")
+	avatar := newTestField("Avatar", "", "*media.Image", nil)
+	avatar.Relation = field.NewRelationWithType(field.HasOne, "Avatar", "media.Image")
+	models := []helperModel{
+		{StructName: "Item", TableName: "items"},
+		{StructName: "Order", TableName: "orders", Relations: []gen.Field{items}},
+		{StructName: "User", TableName: "users", Relations: []gen.Field{orders, avatar}},
+	}
+
+	if err := writePreloadConstants(g, models); err != nil {
+		t.Fatalf("write preload constants: %v", err)
+	}
+
+	outFile := filepath.Join(g.ModelPkgPath, "zz_preload.gen.go")
+	assertFileContains(t, outFile, "package models")
+	assertFileContains(t, outFile, "type OrderPath string")
+	assertFileContains(t, outFile, "func (p OrderPath) Items() ItemPath {\n\treturn ItemPath(string(p) + \".Items\")\n}")
+	assertFileContains(t, outFile, "type ItemPath string")
+	assertFileContains(t, outFile, "var UserRels = struct {\n\tOrders OrderPath\n\tAvatar RelationPath\n}")
+	assertFileContains(t, outFile, "var OrderRels = struct {\n\tItems ItemPath\n}")
+	assertFileNotContains(t, outFile, "UserPath")
+	assertFileNotContains(t, outFile, "ItemRels")
+	assertFileNotContains(t, outFile, "Then(")
+}
+
+func TestWriteSchemaDocSeparatesTablesAndViews(t *testing.T) {
+	t.Parallel()
+
+	outPath := t.TempDir()
+	models := []helperModel{
+		{StructName: "UserSummary", TableName: "user_summary", View: true, Fields: []gen.Field{
+			newTestField("UserID", "user_id", "*int64", field.GormTag{field.TagKeyGormType: {"bigint"}}),
+		}},
+		{StructName: "User", TableName: "users", Fields: []gen.Field{
+			newTestField("ID", "id", "int64", field.GormTag{field.TagKeyGormType: {"bigint"}, field.TagKeyGormPrimaryKey: nil}),
+			newTestField("TenantID", "tenant_id", "int64", field.GormTag{
+				field.TagKeyGormNotNull:     nil,
+				field.TagKeyGormUniqueIndex: {"users_tenant_email_key,priority:1"},
+			}),
+			newTestField("Email", "email", "string", field.GormTag{
+				field.TagKeyGormType:        {"text"},
+				field.TagKeyGormUniqueIndex: {"users_tenant_email_key,priority:2"},
+				field.TagKeyGormComment:     {"login | contact"},
+			}),
+		}},
+	}
+
+	if err := writeSchemaDoc(outPath, models); err != nil {
+		t.Fatalf("write schema doc: %v", err)
+	}
+
+	outFile := filepath.Join(outPath, "SCHEMA.md")
+	content, err := os.ReadFile(outFile)
+	if err != nil {
+		t.Fatalf("read schema doc: %v", err)
+	}
+	tablesAt := strings.Index(string(content), "## Tables")
+	viewsAt := strings.Index(string(content), "## Views")
+	if tablesAt == -1 || viewsAt == -1 || tablesAt > viewsAt {
+		t.Fatalf("expected Tables section before Views section:\n%s", content)
+	}
+	assertFileContains(t, outFile, "Go model: `UserSummary` (view)")
+	assertFileContains(t, outFile, "| `id` | `bigint` | `int64` | no |  |  |")
+	assertFileContains(t, outFile, "| `email` | `text` | `string` | yes |  | login \\| contact |")
+	assertFileContains(t, outFile, "Primary key: `id`")
+	assertFileContains(t, outFile, "- `users_tenant_email_key` (unique): `tenant_id`, `email`")
+}
+
+func TestWriteFindOrCreateUsesUniqueIndexColumns(t *testing.T) {
+	t.Parallel()
+
+	g := newTestGenerator(t)
+	models := []helperModel{
+		{StructName: "Membership", TableName: "memberships", Fields: []gen.Field{
+			newTestField("ID", "id", "int64", field.GormTag{field.TagKeyGormPrimaryKey: nil}),
+			newTestField("UserID", "user_id", "int64", field.GormTag{field.TagKeyGormUniqueIndex: {"memberships_key,priority:2"}}),
+			newTestField("OrgID", "org_id", "int64", field.GormTag{field.TagKeyGormUniqueIndex: {"memberships_key,priority:1"}}),
+		}},
+		{StructName: "Note", TableName: "notes", Fields: []gen.Field{
+			newTestField("ID", "id", "int64", field.GormTag{field.TagKeyGormPrimaryKey: nil}),
+		}},
+	}
+
+	if err := writeFindOrCreate(g, models); err != nil {
+		t.Fatalf("write find-or-create helpers: %v", err)
+	}
+
+	outFile := filepath.Join(g.ModelPkgPath, "zz_find_or_create.gen.go")
+	assertFileContains(t, outFile, "func FindOrCreateMembership(db *gorm.DB, where Membership, defaults Membership) (Membership, bool, error) {")
+	assertFileContains(t, outFile, "\"org_id\":  where.OrgID,\n\t\t\"user_id\": where.UserID,")
+	assertFileContains(t, outFile, "db.Clauses(clause.OnConflict{DoNothing: true}).Create(&created)")
+	assertFileNotContains(t, outFile, "FindOrCreateNote")
+}
+
+func TestWriteSchemaAssertionEmbedsColumnTypes(t *testing.T) {
+	t.Parallel()
+
+	g := newTestGenerator(t)
+	models := []helperModel{
+		{StructName: "User", TableName: "users", Fields: []gen.Field{
+			newTestField("ID", "id", "int64", field.GormTag{field.TagKeyGormType: {"bigint"}}),
+			newTestField("Email", "email", "string", field.GormTag{field.TagKeyGormType: {"character varying(255)"}}),
+		}},
+	}
+
+	if err := writeSchemaAssertion(g, models); err != nil {
+		t.Fatalf("write schema assertion: %v", err)
+	}
+
+	outFile := filepath.Join(g.ModelPkgPath, "zz_schema_assert.gen.go")
+	assertFileContains(t, outFile, "func AssertSchema(db *gorm.DB) error {")
+	assertFileContains(t, outFile, `{Model: "User", Table: "users", Columns: []schemaAssertionColumn{`)
+	assertFileContains(t, outFile, `{Name: "email", Type: "character varying(255)"},`)
+	assertFileNotContains(t, outFile, "pg_attribute")
+}
+
+func TestWriteSchemaAssertionReadsMaterializedViewsFromPgAttribute(t *testing.T) {
+	t.Parallel()
+
+	g := newTestGenerator(t)
+	models := []helperModel{
+		{StructName: "User", TableName: "users", Fields: []gen.Field{
+			newTestField("ID", "id", "int64", field.GormTag{field.TagKeyGormType: {"bigint"}}),
+		}},
+		{StructName: "UserTotal", TableName: "user_totals", View: true, MaterializedView: true, Fields: []gen.Field{
+			newTestField("UserID", "user_id", "int64", field.GormTag{field.TagKeyGormType: {"bigint"}}),
+			newTestField("Total", "total", "float64", field.GormTag{field.TagKeyGormType: {"numeric(12,2)"}}),
+		}},
+	}
+
+	if err := writeSchemaAssertion(g, models); err != nil {
+		t.Fatalf("write schema assertion: %v", err)
+	}
+
+	outFile := filepath.Join(g.ModelPkgPath, "zz_schema_assert.gen.go")
+	assertFileContains(t, outFile, `{Model: "User", Table: "users", Columns: []schemaAssertionColumn{`)
+	assertFileContains(t, outFile, `{Model: "UserTotal", Table: "user_totals", MaterializedView: true, Columns: []schemaAssertionColumn{`)
+	assertFileContains(t, outFile, `{Name: "total", Type: "numeric(12,2)"},`)
+	assertFileContains(t, outFile, "actual, err := schemaColumnTypes(db, model.Table, model.MaterializedView)")
+	assertFileContains(t, outFile, "FROM pg_attribute a")
+	assertFileContains(t, outFile, "AND c.relkind = 'm'")
+}
+
+func TestWriteScopesFollowsColumnConventions(t *testing.T) {
+	t.Parallel()
+
+	g := newTestGenerator(t)
+	models := []helperModel{
+		{StructName: "Account", TableName: "accounts", Fields: []gen.Field{
+			newTestField("ID", "id", "datatypes.UUID", field.GormTag{field.TagKeyGormPrimaryKey: nil}),
+			newTestField("IsActive", "is_active", "*bool", nil),
+			newTestField("DeletedAt", "deleted_at", "gorm.DeletedAt", nil),
+		}},
+		{StructName: "Membership", TableName: "memberships", Fields: []gen.Field{
+			newTestField("UserID", "user_id", "int64", field.GormTag{field.TagKeyGormPrimaryKey: nil}),
+			newTestField("OrgID", "org_id", "int64", field.GormTag{field.TagKeyGormPrimaryKey: nil}),
+		}},
+	}
+
+	if err := writeScopes(g, models, []string{"gorm.io/datatypes"}); err != nil {
+		t.Fatalf("write scopes: %v", err)
+	}
+
+	outFile := filepath.Join(g.ModelPkgPath, "zz_scopes.gen.go")
+	assertFileContains(t, outFile, `"gorm.io/datatypes"`)
+	assertFileContains(t, outFile, "func AccountActive(db *gorm.DB) *gorm.DB {")
+	assertFileContains(t, outFile, `Name: "is_active"}, Value: true})`)
+	assertFileContains(t, outFile, "func AccountNotDeleted(db *gorm.DB) *gorm.DB {")
+	assertFileContains(t, outFile, "func AccountByID(id datatypes.UUID) func(*gorm.DB) *gorm.DB {")
+	assertFileNotContains(t, outFile, "Membership")
+}
+
+func TestWriteUpdateHelpersEmitsTypedPartialUpdates(t *testing.T) {
+	t.Parallel()
+
+	g := newTestGenerator(t)
+	models := []helperModel{
+		{StructName: "User", TableName: "users", Fields: []gen.Field{
+			newTestField("ID", "id", "int64", field.GormTag{field.TagKeyGormPrimaryKey: nil}),
+			newTestField("Email", "email", "string", nil),
+			newTestField("LastSeenAt", "last_seen_at", "*time.Time", nil),
+			newTestField("Location", "location", "geo.Point", nil),
+			newTestField("DeletedAt", "deleted_at", "gorm.DeletedAt", nil),
+		}},
+		{StructName: "UserSummary", TableName: "user_summary", View: true, Fields: []gen.Field{
+			newTestField("ID", "id", "int64", field.GormTag{field.TagKeyGormPrimaryKey: nil}),
+		}},
+	}
+
+	var logs bytes.Buffer
+	if err := writeUpdateHelpers(slog.New(slog.NewTextHandler(&logs, nil)), g, models, nil); err != nil {
+		t.Fatalf("write update helpers: %v", err)
+	}
+
+	outFile := filepath.Join(g.ModelPkgPath, "zz_update.gen.go")
+	assertFileContains(t, outFile, "\t\"time\"\n")
+	assertFileContains(t, outFile, "type UserUpdates struct {\n\tEmail      *string\n\tLastSeenAt **time.Time\n}")
+	assertFileContains(t, outFile, "columns[\"last_seen_at\"] = *u.LastSeenAt")
+	assertFileContains(t, outFile, "func UpdateUser(db *gorm.DB, id int64, updates UserUpdates) error {")
+	assertFileNotContains(t, outFile, "DeletedAt")
+	assertFileNotContains(t, outFile, "UserSummary")
+	assertFileNotContains(t, outFile, "Location")
+	if !strings.Contains(logs.String(), "column=location") {
+		t.Fatalf("expected the unresolved location column to be logged, got %q", logs.String())
+	}
+}
+
+func TestWriteERDDrawsForeignKeysBetweenGeneratedModels(t *testing.T) {
+	t.Parallel()
+
+	outPath := t.TempDir()
+	models := []helperModel{
+		{StructName: "Order", TableName: "orders"},
+		{StructName: "User", TableName: "users"},
+		{StructName: "UserSummary", TableName: "user_summary", View: true},
+	}
+	foreignKeys := []foreignKey{
+		{Table: "orders", Columns: []string{"tenant_id", "user_id"}, RefTable: "users", RefColumns: []string{"tenant_id", "id"}},
+		{Table: "orders", Columns: []string{"coupon_id"}, RefTable: "coupons", RefColumns: []string{"id"}},
+	}
+
+	if err := writeERD(outPath, models, foreignKeys); err != nil {
+		t.Fatalf("write ERD: %v", err)
+	}
+
+	outFile := filepath.Join(outPath, "schema_gen.dot")
+	assertFileContains(t, outFile, "digraph schema {")
+	assertFileContains(t, outFile, `"users" [label="users\nUser"];`)
+	assertFileContains(t, outFile, `"user_summary" [label="user_summary\nUserSummary", style=dashed];`)
+	assertFileContains(t, outFile, `"orders" -> "users" [label="tenant_id, user_id"];`)
+	assertFileNotContains(t, outFile, "coupons")
+}
+
+func TestWriteExistsUsesPrimaryAndUniqueKeys(t *testing.T) {
+	t.Parallel()
+
+	g := newTestGenerator(t)
+	models := []helperModel{
+		{StructName: "Membership", TableName: "memberships", Fields: []gen.Field{
+			newTestField("ID", "id", "int64", field.GormTag{field.TagKeyGormPrimaryKey: nil}),
+			newTestField("OrgID", "org_id", "int64", field.GormTag{field.TagKeyGormUniqueIndex: {"memberships_key,priority:1"}}),
+			newTestField("Type", "type", "*string", field.GormTag{field.TagKeyGormUniqueIndex: {"memberships_key,priority:2"}}),
+		}},
+	}
+
+	if err := writeExists(g, models, nil); err != nil {
+		t.Fatalf("write exists helpers: %v", err)
+	}
+
+	outFile := filepath.Join(g.ModelPkgPath, "zz_exists.gen.go")
+	assertFileContains(t, outFile, "func MembershipExistsByID(db *gorm.DB, id int64) (bool, error) {")
+	assertFileContains(t, outFile, "func MembershipExistsByOrgIDType(db *gorm.DB, orgID int64, typeValue string) (bool, error) {")
+	assertFileContains(t, outFile, `Select("1").`)
+	assertFileContains(t, outFile, "Limit(1).Find(&found)")
+}
+
+func TestWriteTenantScopeCoversTablesWithTheColumn(t *testing.T) {
+	t.Parallel()
+
+	g := newTestGenerator(t)
+	models := []helperModel{
+		{StructName: "Order", TableName: "orders", Fields: []gen.Field{
+			newTestField("ID", "id", "int64", field.GormTag{field.TagKeyGormPrimaryKey: nil}),
+			newTestField("TenantID", "tenant_id", "datatypes.UUID", nil),
+		}},
+		{StructName: "Invoice", TableName: "invoices", Fields: []gen.Field{
+			newTestField("TenantID", "tenant_id", "*datatypes.UUID", nil),
+		}},
+		{StructName: "Country", TableName: "countries", Fields: []gen.Field{
+			newTestField("Code", "code", "string", nil),
+		}},
+	}
+
+	if err := writeTenantScope(g, models, "tenant_id", true, nil); err != nil {
+		t.Fatalf("write tenant scope: %v", err)
+	}
+
+	outFile := filepath.Join(g.ModelPkgPath, "zz_tenant.gen.go")
+	assertFileContains(t, outFile, `"gorm.io/datatypes"`)
+	assertFileContains(t, outFile, "func ScopeByTenant(tenantID datatypes.UUID) func(*gorm.DB) *gorm.DB {")
+	assertFileContains(t, outFile, `"invoices": {},`)
+	assertFileContains(t, outFile, `"orders":   {},`)
+	assertFileContains(t, outFile, "func RegisterTenantEnforcement(db *gorm.DB) error {")
+	assertFileNotContains(t, outFile, "countries")
+
+	models[1].Fields[0].Type = "string"
+	if err := writeTenantScope(g, models, "tenant_id", false, nil); err == nil || !strings.Contains(err.Error(), "has type string") {
+		t.Fatalf("expected a tenant column type mismatch error, got %v", err)
+	}
+}
+
+func TestWriteMapConversionKeysByColumnName(t *testing.T) {
+	t.Parallel()
+
+	g := newTestGenerator(t)
+	models := []helperModel{
+		{StructName: "Event", TableName: "events", Fields: []gen.Field{
+			newTestField("ID", "id", "int64", nil),
+			newTestField("Payload", "payload", "*datatypes.JSON", nil),
+		}},
+	}
+
+	if err := writeMapConversion(g, models); err != nil {
+		t.Fatalf("write map conversion: %v", err)
+	}
+
+	outFile := filepath.Join(g.ModelPkgPath, "zz_map.gen.go")
+	assertFileContains(t, outFile, "func (m Event) ToMap() map[string]any {")
+	assertFileContains(t, outFile, `"payload": m.Payload,`)
+	assertFileContains(t, outFile, "func EventFromMap(values map[string]any) (Event, error) {")
+	assertFileContains(t, outFile, "err = assignMapValue(&m.Payload, values[column])")
+	assertFileNotContains(t, outFile, "gorm.io/datatypes")
+}
+
+func newTestGenerator(t *testing.T) *gen.Generator {
+	t.Helper()
+
+	outPath := filepath.Join(t.TempDir(), "generated")
+	modelPath := filepath.Join(outPath, "models")
+	if err := os.MkdirAll(modelPath, 0o755); err != nil {
+		t.Fatalf("mkdir %s: %v", modelPath, err)
+	}
+	return gen.NewGenerator(gen.Config{
+		OutPath:      outPath,
+		ModelPkgPath: modelPath,
+	})
+}
+
+func assertFileNotContains(t *testing.T, path string, substring string) {
+	t.Helper()
+
+	content, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("read %s: %v", path, err)
+	}
+	if strings.Contains(string(content), substring) {
+		t.Fatalf("expected %s not to contain %q", path, substring)
+	}
+}
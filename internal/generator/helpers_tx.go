@@ -0,0 +1,58 @@
+package generator
+
+import (
+	"path/filepath"
+	"sort"
+
+	"gorm.io/gen"
+)
+
+type txQueryObject struct {
+	StructName      string
+	QueryStructName string
+}
+
+// writeTxHelpers emits a WithTx method per query object in the query package.
+// gen rebinds a single query object only through the unexported replaceDB, so
+// code handed a *gorm.DB by db.Transaction would otherwise have to rebind the
+// whole querier with Q.ReplaceDB(tx) to reach one object.
+func writeTxHelpers(g *gen.Generator, refs []modelRef) error {
+	data := struct {
+		PackageName string
+		Objects     []txQueryObject
+	}{
+		PackageName: filepath.Base(g.OutPath),
+	}
+	for _, ref := range refs {
+		info, ok := g.Data[ref.StructName]
+		if !ok || info == nil || info.QueryStructName == "" {
+			continue
+		}
+		data.Objects = append(data.Objects, txQueryObject{
+			StructName:      ref.StructName,
+			QueryStructName: info.QueryStructName,
+		})
+	}
+	if len(data.Objects) == 0 {
+		return nil
+	}
+	sort.Slice(data.Objects, func(i, j int) bool { return data.Objects[i].StructName < data.Objects[j].StructName })
+
+	rendered, err := renderTemplate("tx_helpers", txHelpersTemplate, data)
+	if err != nil {
+		return err
+	}
+	return writeFormattedGoFile(filepath.Join(g.OutPath, "zz_tx.gen.go"), rendered)
+}
+
+const txHelpersTemplate = `// Code generated by gormdb2struct; DO NOT EDIT.
+package {{.PackageName}}
+
+import "gorm.io/gorm"
+{{range .Objects}}
+// WithTx returns a copy of the {{.StructName}} query object bound to tx.
+func (q {{.QueryStructName}}) WithTx(tx *gorm.DB) *{{.QueryStructName}} {
+	q = q.replaceDB(tx)
+	return &q
+}
+{{end}}`
@@ -0,0 +1,150 @@
+package generator
+
+import (
+	"log/slog"
+	"path/filepath"
+	"strings"
+
+	"gorm.io/gen"
+	"gorm.io/gen/field"
+)
+
+type updateColumn struct {
+	FieldName  string
+	ColumnName string
+	Type       string
+}
+
+type updateModel struct {
+	StructName       string
+	PrimaryKeyColumn string
+	PrimaryKeyType   string
+	Columns          []updateColumn
+}
+
+// writeUpdateHelpers emits a <Model>Updates struct with one pointer field per
+// column and an Update<Model>(db, pk, updates) function that turns the non-nil
+// fields into a column-keyed Updates map. A nullable column, modeled as a
+// pointer, gets a pointer to that pointer, so a non-nil field holding nil sets
+// the column to NULL. Views and tables without a single-column primary key are
+// skipped, and so is any column whose Go type has no known import path.
+func writeUpdateHelpers(logger *slog.Logger, g *gen.Generator, models []helperModel, importPaths []string) error {
+	imports := map[string]struct{}{}
+	data := struct {
+		PackageName string
+		Imports     []string
+		Models      []updateModel
+	}{
+		PackageName: modelsPackageName(g),
+	}
+	for _, model := range models {
+		if model.View {
+			continue
+		}
+		var primaryKeys []gen.Field
+		for _, fld := range model.Fields {
+			if _, isPrimaryKey := fld.GORMTag[field.TagKeyGormPrimaryKey]; isPrimaryKey {
+				primaryKeys = append(primaryKeys, fld)
+			}
+		}
+		if len(primaryKeys) != 1 {
+			continue
+		}
+		keyType := strings.TrimLeft(primaryKeys[0].Type, "*")
+		keyImport, ok := qualifiedTypeImport(keyType, importPaths)
+		if !ok {
+			logger.Warn("Skipping update helper; the primary key type has no known import path, add it to ImportPackagePaths",
+				slog.String("model", model.StructName),
+				slog.String("type", keyType),
+			)
+			continue
+		}
+
+		entry := updateModel{
+			StructName:       model.StructName,
+			PrimaryKeyColumn: primaryKeys[0].ColumnName,
+			PrimaryKeyType:   keyType,
+		}
+		columnImports := map[string]struct{}{keyImport: {}}
+		for _, fld := range model.Fields {
+			if fld == primaryKeys[0] || fld.ColumnName == "" || strings.TrimLeft(fld.Type, "*") == "gorm.DeletedAt" {
+				continue
+			}
+			importPath, ok := qualifiedTypeImport(fld.Type, importPaths)
+			if !ok {
+				logger.Warn("Skipping column in update helper; its type has no known import path, add it to ImportPackagePaths",
+					slog.String("model", model.StructName),
+					slog.String("column", fld.ColumnName),
+					slog.String("type", fld.Type),
+				)
+				continue
+			}
+			columnImports[importPath] = struct{}{}
+			entry.Columns = append(entry.Columns, updateColumn{
+				FieldName:  fld.Name,
+				ColumnName: fld.ColumnName,
+				Type:       fld.Type,
+			})
+		}
+		if len(entry.Columns) == 0 {
+			continue
+		}
+		for importPath := range columnImports {
+			imports[importPath] = struct{}{}
+		}
+		data.Models = append(data.Models, entry)
+	}
+	if len(data.Models) == 0 {
+		return nil
+	}
+	data.Imports = helperImports(imports, "gorm.io/gorm", "gorm.io/gorm/clause")
+
+	rendered, err := renderTemplate("update_helpers", updateHelpersTemplate, data)
+	if err != nil {
+		return err
+	}
+	return writeFormattedGoFile(filepath.Join(g.ModelPkgPath, "zz_update.gen.go"), rendered)
+}
+
+const updateHelpersTemplate = `// Code generated by gormdb2struct; DO NOT EDIT.
+package {{.PackageName}}
+
+import (
+	{{- range .Imports}}
+	{{printf "%q" .}}
+	{{- end}}
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+{{range .Models}}
+// {{.StructName}}Updates holds a partial update of {{.StructName}}. Nil fields are left unchanged;
+// a nullable column is set to NULL by a non-nil field that points to nil.
+type {{.StructName}}Updates struct {
+{{- range .Columns}}
+	{{.FieldName}} *{{.Type}}
+{{- end}}
+}
+
+// Columns returns the non-nil fields keyed by column name.
+func (u {{.StructName}}Updates) Columns() map[string]any {
+	columns := map[string]any{}
+{{- range .Columns}}
+	if u.{{.FieldName}} != nil {
+		columns[{{printf "%q" .ColumnName}}] = *u.{{.FieldName}}
+	}
+{{- end}}
+	return columns
+}
+
+// Update{{.StructName}} applies the non-nil fields of updates to the row with the given {{.PrimaryKeyColumn}}.
+// It does nothing when no field is set.
+func Update{{.StructName}}(db *gorm.DB, id {{.PrimaryKeyType}}, updates {{.StructName}}Updates) error {
+	columns := updates.Columns()
+	if len(columns) == 0 {
+		return nil
+	}
+	return db.Model(&{{.StructName}}{}).
+		Where(clause.Eq{Column: clause.Column{Table: clause.CurrentTable, Name: {{printf "%q" .PrimaryKeyColumn}}}, Value: id}).
+		Updates(columns).Error
+}
+{{end}}`
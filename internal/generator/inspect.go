@@ -4,10 +4,12 @@ import (
 	"bytes"
 	"context"
 	"fmt"
+	"regexp"
 	"sort"
 	"strings"
 
 	"github.com/dan-sherwin/gormdb2struct/internal/config"
+	"github.com/dan-sherwin/gormdb2struct/pgtypes"
 	"github.com/iancoleman/strcase"
 	"gorm.io/gorm"
 )
@@ -42,6 +44,10 @@ type InspectionReport struct {
 	Dialect  config.DatabaseDialect
 	Objects  []InspectionObject
 	Findings []InspectionTypeFinding
+	// UnmappedTypes lists the built-in column types that neither TypeMap nor
+	// pgtypes.PgTypeMap covers, so gen falls back to the Go type the driver
+	// reports as the column's ScanType.
+	UnmappedTypes []string
 }
 
 type InspectionObject struct {
@@ -117,6 +123,7 @@ func (s *Service) inspectPostgres(ctx context.Context, cfg config.Config) (Inspe
 	if err != nil {
 		return InspectionReport{}, err
 	}
+	objects = excludePostgresObjects(s.logger, cfg, objects)
 
 	columns, err := loadPostgresInspectionColumns(db, objects)
 	if err != nil {
@@ -208,9 +215,13 @@ func buildPostgresInspectionReport(
 	}
 
 	findingsByType := make(map[string]*InspectionTypeFinding)
+	unmapped := make(map[string]struct{})
 	for _, column := range columns {
 		typeInfo, ok := classifyPostgresInspectionType(column, enumMeta, domainMeta)
 		if !ok {
+			if dbType, mapped := postgresBuiltinTypeMapping(cfg, column); !mapped {
+				unmapped[dbType] = struct{}{}
+			}
 			continue
 		}
 
@@ -252,9 +263,40 @@ func buildPostgresInspectionReport(
 		report.Findings = append(report.Findings, *finding)
 	}
 
+	report.UnmappedTypes = make([]string, 0, len(unmapped))
+	for dbType := range unmapped {
+		report.UnmappedTypes = append(report.UnmappedTypes, dbType)
+	}
+	sort.Strings(report.UnmappedTypes)
+
 	return report
 }
 
+var postgresTypeModifierPattern = regexp.MustCompile(`\([^)]*\)`)
+
+// postgresBuiltinTypeMapping reports the TypeMap key of a built-in column type
+// and whether generation has a mapping for it. Scalars are keyed by their
+// internal name (int4, jsonb); arrays by their formatted name (integer[]),
+// which is what the data type map sees for them.
+func postgresBuiltinTypeMapping(cfg config.Config, column postgresInspectionColumnRow) (string, bool) {
+	candidates := []string{column.TypeName}
+	if column.ElementTypeName != "" && strings.HasPrefix(column.TypeName, "_") {
+		formatted := strings.TrimSpace(postgresTypeModifierPattern.ReplaceAllString(column.ColumnType, ""))
+		candidates = []string{formatted, column.ElementTypeName + "[]"}
+	} else {
+		candidates = append(candidates, normalizeColumnType(column.ColumnType))
+	}
+	for _, candidate := range candidates {
+		if _, ok := pgtypes.PgTypeMap[candidate]; ok {
+			return candidates[0], true
+		}
+		if _, ok := cfg.TypeMap[candidate]; ok {
+			return candidates[0], true
+		}
+	}
+	return candidates[0], false
+}
+
 func classifyPostgresInspectionType(
 	row postgresInspectionColumnRow,
 	enumMeta map[string]postgresEnumMetadata,
@@ -473,6 +515,10 @@ func renderInspectionText(report InspectionReport) string {
 		len(manual),
 	)
 
+	if len(report.UnmappedTypes) > 0 {
+		_, _ = fmt.Fprintf(&buf, "Unmapped built-in types (generated as string): %s\n", strings.Join(report.UnmappedTypes, ", "))
+	}
+
 	if len(report.Findings) == 0 {
 		buf.WriteString("\nNo PostgreSQL enums, domains, or other custom types were found in the selected objects.\n")
 		return buf.String()
@@ -70,7 +70,7 @@ func RenderInspectionStarterConfig(cfg config.Config, report InspectionReport) s
 	builder.WriteString("[TypeMap]\n")
 	typeMappedFindings := inspectionTypeMappedFindings(report.Findings)
 	manualFindings := inspectionManualFindings(report.Findings)
-	if len(typeMappedFindings) == 0 && len(manualFindings) == 0 {
+	if len(typeMappedFindings) == 0 && len(manualFindings) == 0 && len(report.UnmappedTypes) == 0 {
 		builder.WriteString("# \"jsonb\" = \"datatypes.JSON\"\n")
 		builder.WriteString("# \"uuid\" = \"datatypes.UUID\"\n")
 		builder.WriteString("# \"my_text_domain\" = \"string\"\n")
@@ -85,6 +85,12 @@ func RenderInspectionStarterConfig(cfg config.Config, report InspectionReport) s
 			}
 			builder.WriteString(line + "\n")
 		}
+		if len(report.UnmappedTypes) > 0 {
+			builder.WriteString("# No mapping yet; these columns are generated as string:\n")
+		}
+		for _, dbType := range report.UnmappedTypes {
+			_, _ = fmt.Fprintf(&builder, "# %q = \"???\"\n", dbType)
+		}
 	}
 
 	builder.WriteString("\n# ExtraFields: add relation fields to specific models (optional)\n")
@@ -86,6 +86,35 @@ func TestBuildPostgresInspectionReportRecommendsGeneratedTypes(t *testing.T) {
 	}
 }
 
+func TestBuildPostgresInspectionReportListsUnmappedBuiltinTypes(t *testing.T) {
+	cfg := config.Config{DatabaseDialect: config.PostgreSQL}
+	cfg.Normalize()
+
+	report := buildPostgresInspectionReport(
+		cfg,
+		[]postgresObject{{Name: "hosts", Kind: postgresObjectTable}},
+		[]postgresInspectionColumnRow{
+			{ObjectName: "hosts", ColumnName: "id", ColumnType: "bigint", TypeSchema: "pg_catalog", TypeName: "int8", TypeKind: "b"},
+			{ObjectName: "hosts", ColumnName: "attrs", ColumnType: "jsonb", TypeSchema: "pg_catalog", TypeName: "jsonb", TypeKind: "b"},
+			{ObjectName: "hosts", ColumnName: "labels", ColumnType: "character varying(20)[]", TypeSchema: "pg_catalog", TypeName: "_varchar", TypeKind: "b", ElementSchema: "pg_catalog", ElementTypeName: "varchar", ElementTypeKind: "b"},
+			{ObjectName: "hosts", ColumnName: "hstore_tags", ColumnType: "hstore", TypeSchema: "pg_catalog", TypeName: "hstore", TypeKind: "b"},
+			{ObjectName: "hosts", ColumnName: "ports", ColumnType: "smallint[]", TypeSchema: "pg_catalog", TypeName: "_int2", TypeKind: "b", ElementSchema: "pg_catalog", ElementTypeName: "int2", ElementTypeKind: "b"},
+			{ObjectName: "hosts", ColumnName: "backup_ports", ColumnType: "smallint[]", TypeSchema: "pg_catalog", TypeName: "_int2", TypeKind: "b", ElementSchema: "pg_catalog", ElementTypeName: "int2", ElementTypeKind: "b"},
+		},
+		nil,
+		nil,
+	)
+
+	if got := strings.Join(report.UnmappedTypes, ","); got != "hstore,smallint[]" {
+		t.Fatalf("expected hstore and smallint[] to be unmapped, got %v", report.UnmappedTypes)
+	}
+
+	rendered := RenderInspectionStarterConfig(cfg, report)
+	if !strings.Contains(rendered, "# \"hstore\" = \"???\"\n# \"smallint[]\" = \"???\"\n") {
+		t.Fatalf("expected unmapped types as TypeMap placeholders, got:\n%s", rendered)
+	}
+}
+
 func TestBuildPostgresInspectionReportMarksEnumArrayManualWhenBaseEnumIsManual(t *testing.T) {
 	cfg := config.Config{
 		DatabaseDialect: config.PostgreSQL,
@@ -0,0 +1,290 @@
+package generator
+
+import (
+	"fmt"
+	"log/slog"
+	"path"
+	"slices"
+	"strings"
+
+	"github.com/dan-sherwin/gormdb2struct/internal/config"
+	"gorm.io/gen"
+	"gorm.io/gen/field"
+)
+
+// modelRef points at the mutable parts of one gen model. gen's model type is
+// unexported, so the generation loops hand over pointers to its fields.
+type modelRef struct {
+	TableName        string
+	StructName       string
+	View             bool
+	MaterializedView bool
+	Fields           *[]gen.Field
+}
+
+// checkStructNameCollisions fails when two objects map to the same model
+// struct name, such as tbl_customer and customer with TrimTablePrefixes =
+// ["tbl_"], which would otherwise leave only one of the models.
+func checkStructNameCollisions(cfg config.Config, objectNames []string) error {
+	seen := make(map[string]string, len(objectNames))
+	for _, name := range objectNames {
+		structName := cfg.ModelStructName(name)
+		if previous, ok := seen[structName]; ok {
+			return fmt.Errorf("objects %q and %q both map to model struct %s; adjust TrimTablePrefixes, NamingStrategyByTable, or ExcludeTables", previous, name, structName)
+		}
+		seen[structName] = name
+	}
+	return nil
+}
+
+func appendExtraFields(fields *[]gen.Field, extraFields []config.ExtraField, strategy config.JSONTagStrategy) {
+	for _, extraField := range extraFields {
+		fieldFactory := gen.FieldNew("", "", nil)
+		fld := fieldFactory(nil)
+		genRelationField(extraField, gen.Field(fld), strategy)
+		*fields = append(*fields, fld)
+	}
+}
+
+func applyJSONTagOverrides(fields []gen.Field, overrides map[string]string) {
+	if len(overrides) == 0 {
+		return
+	}
+	for _, fld := range fields {
+		if jsonTag, exists := overrides[fld.ColumnName]; exists {
+			fld.Tag.Set("json", jsonTag)
+			continue
+		}
+		if jsonTag, exists := overrides[fld.Name]; exists {
+			fld.Tag.Set("json", jsonTag)
+		}
+	}
+}
+
+// applySensitiveColumns hides the configured columns from JSON. It runs after
+// applyJSONTagOverrides so a sensitive column can never be re-exposed by an
+// override, and it fails on unknown columns so a typo does not leak data.
+func applySensitiveColumns(tableName string, fields []gen.Field, columns []string) error {
+	for _, column := range columns {
+		fld := findFieldByColumn(fields, column)
+		if fld == nil {
+			return fmt.Errorf("SensitiveColumns[%q]: column %q not found", tableName, column)
+		}
+		fld.Tag.Set("json", "-")
+	}
+	return nil
+}
+
+// gormReadOnlyTag is GORM's field permission for read-only columns.
+const gormReadOnlyTag = "->"
+
+// applyReadOnlyFields tags every column of a view model read-only, so Create,
+// Save, and Updates skip them instead of writing to a view. Relation fields
+// added through ExtraFields are left writable.
+func applyReadOnlyFields(fields []gen.Field) {
+	for _, fld := range fields {
+		if fld == nil || fld.IsRelation() || fld.ColumnName == "" {
+			continue
+		}
+		if fld.GORMTag == nil {
+			fld.GORMTag = field.GormTag{}
+		}
+		fld.GORMTag.Set(gormReadOnlyTag)
+	}
+}
+
+// viewStructNames lists the struct names of the view models among refs.
+func viewStructNames(refs []modelRef) []string {
+	var names []string
+	for _, ref := range refs {
+		if ref.View {
+			names = append(names, ref.StructName)
+		}
+	}
+	return names
+}
+
+// softDeleteOptions selects the column modeled as gorm.DeletedAt, which makes
+// GORM and the gen query objects skip soft-deleted rows unless Unscoped.
+type softDeleteOptions struct {
+	// Column is the soft-delete column; "" turns soft deletes off.
+	Column string
+	// Enabled is set by EnableSoftDelete = true. The column then also gets
+	// gorm:"index" and must have a timestamp database type.
+	Enabled bool
+}
+
+func newSoftDeleteOptions(cfg config.Config) softDeleteOptions {
+	return softDeleteOptions{
+		Column:  cfg.SoftDeleteColumnName(),
+		Enabled: cfg.EnableSoftDelete != nil && *cfg.EnableSoftDelete,
+	}
+}
+
+// applySoftDeleteFields types the soft-delete timestamp as gorm.DeletedAt. gen
+// only converts a bare time.Time deleted_at, so a type map that already
+// produced *time.Time, as the SQLite map does, would otherwise turn soft
+// deletes into hard deletes. A deleted_at that gen converted but that is not
+// the soft-delete column goes back to time.Time. isTimestamp, when not nil,
+// reports whether a column's database type is a timestamp rather than, say, a
+// date that also maps to time.Time.
+func applySoftDeleteFields(fields []gen.Field, opts softDeleteOptions, isTimestamp func(column string) bool) {
+	for _, fld := range fields {
+		if fld == nil || fld.IsRelation() || fld.ColumnName == "" {
+			continue
+		}
+		if fld.ColumnName != opts.Column {
+			if fld.ColumnName == config.DefaultSoftDeleteColumn && fld.Type == "gorm.DeletedAt" {
+				fld.Type = "time.Time"
+			}
+			continue
+		}
+		if fld.Type != "time.Time" && fld.Type != "*time.Time" && fld.Type != "gorm.DeletedAt" {
+			continue
+		}
+		if !opts.Enabled {
+			fld.Type = "gorm.DeletedAt"
+			continue
+		}
+		if isTimestamp != nil && !isTimestamp(fld.ColumnName) {
+			if fld.Type == "gorm.DeletedAt" {
+				fld.Type = "time.Time"
+			}
+			continue
+		}
+		fld.Type = "gorm.DeletedAt"
+		if fld.GORMTag == nil {
+			fld.GORMTag = field.GormTag{}
+		}
+		if _, ok := fld.GORMTag[field.TagKeyGormIndex]; !ok {
+			fld.GORMTag.Set(field.TagKeyGormIndex)
+		}
+	}
+}
+
+// applyColumnTypeOverrides sets the ColumnTypeOverridesByTable types, keyed by
+// database column name like JSONTagOverridesByTable. A nullable column keeps
+// its pointer unless the override is a pointer type itself, and columns the
+// model does not have are ignored.
+func applyColumnTypeOverrides(fields []gen.Field, overrides map[string]string) {
+	for column, goType := range overrides {
+		fld := findFieldByColumn(fields, column)
+		if fld == nil {
+			continue
+		}
+		goType = strings.TrimSpace(goType)
+		if strings.HasPrefix(fld.Type, "*") && !strings.HasPrefix(goType, "*") {
+			goType = "*" + goType
+		}
+		fld.Type = goType
+	}
+}
+
+func findFieldByColumn(fields []gen.Field, name string) gen.Field {
+	for _, fld := range fields {
+		if fld != nil && !fld.IsRelation() && (fld.ColumnName == name || fld.Name == name) {
+			return fld
+		}
+	}
+	return nil
+}
+
+// applyModelFileName renders ModelFileNamePattern into the gen model's
+// FileName and rejects patterns that map two models to the same file.
+func applyModelFileName(cfg config.Config, fileName *string, tableName, structName string, seen map[string]string) error {
+	name, err := cfg.ModelFileName(tableName, structName)
+	if err != nil || name == "" {
+		return err
+	}
+	if previous, exists := seen[name]; exists {
+		return fmt.Errorf("ModelFileNamePattern renders %q for both %q and %q", name+".gen.go", previous, tableName)
+	}
+	seen[name] = tableName
+	*fileName = name
+	return nil
+}
+
+// checkMaxFields reports models whose column field count exceeds MaxFields,
+// as a warning or, with FailFast, as an error. It runs after TransformModels so
+// fields dropped by the hook no longer count.
+func checkMaxFields(logger *slog.Logger, cfg config.Config, refs []modelRef) error {
+	if cfg.MaxFields <= 0 {
+		return nil
+	}
+	for _, ref := range refs {
+		count := 0
+		for _, fld := range *ref.Fields {
+			if fld != nil && !fld.IsRelation() {
+				count++
+			}
+		}
+		if count <= cfg.MaxFields {
+			continue
+		}
+		if cfg.FailFast {
+			return fmt.Errorf("%s (%s) has %d fields, more than MaxFields = %d; leave the object out of Objects, add it to ExcludeTables, or raise MaxFields", ref.StructName, ref.TableName, count, cfg.MaxFields)
+		}
+		logger.Warn("Generated struct exceeds MaxFields; leave the object out of Objects, add it to ExcludeTables, or raise MaxFields",
+			slog.String("table", ref.TableName),
+			slog.String("struct", ref.StructName),
+			slog.Int("fields", count),
+			slog.Int("max_fields", cfg.MaxFields),
+		)
+	}
+	return nil
+}
+
+// excludeObject reports whether ExcludeTables or ExcludeMaterializedViews, as
+// given in patterns, drops the object name. Exclusion wins over Objects, so a
+// listed object that matches is logged at Info to explain why it is missing.
+func excludeObject(logger *slog.Logger, cfg config.Config, option string, patterns []string, name string) bool {
+	for _, pattern := range patterns {
+		if matched, _ := path.Match(pattern, name); !matched {
+			continue
+		}
+		if cfg.Objects != nil && slices.Contains(*cfg.Objects, name) {
+			logger.Info("Excluding object listed in Objects",
+				slog.String("object", name),
+				slog.String("option", option),
+				slog.String("pattern", pattern),
+			)
+		} else {
+			logger.Debug("Excluding object",
+				slog.String("object", name),
+				slog.String("option", option),
+				slog.String("pattern", pattern),
+			)
+		}
+		return true
+	}
+	return false
+}
+
+// applyTransformModels runs the configured TransformModels hook and writes the
+// edited field lists back into the gen models before ApplyBasic.
+func applyTransformModels(transform func([]*config.GeneratedModel), refs []modelRef) {
+	if transform == nil || len(refs) == 0 {
+		return
+	}
+
+	views := make([]*config.GeneratedModel, len(refs))
+	for i, ref := range refs {
+		views[i] = &config.GeneratedModel{
+			TableName:  ref.TableName,
+			StructName: ref.StructName,
+			Fields:     *ref.Fields,
+		}
+	}
+
+	transform(views)
+
+	for i, ref := range refs {
+		fields := make([]gen.Field, 0, len(views[i].Fields))
+		for _, fld := range views[i].Fields {
+			if fld != nil {
+				fields = append(fields, fld)
+			}
+		}
+		*ref.Fields = fields
+	}
+}
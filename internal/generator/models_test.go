@@ -0,0 +1,190 @@
+package generator
+
+import (
+	"bytes"
+	"log/slog"
+	"strings"
+	"testing"
+
+	"github.com/dan-sherwin/gormdb2struct/internal/config"
+	"gorm.io/gen"
+	"gorm.io/gen/field"
+)
+
+func TestApplyTransformModelsWritesFieldEditsBack(t *testing.T) {
+	t.Parallel()
+
+	fields := []gen.Field{
+		newTestField("ID", "id", "int64", nil),
+		newTestField("Secret", "secret", "string", nil),
+	}
+	refs := []modelRef{{TableName: "users", StructName: "User", Fields: &fields}}
+
+	applyTransformModels(func(models []*config.GeneratedModel) {
+		if len(models) != 1 || models[0].TableName != "users" || models[0].StructName != "User" {
+			t.Fatalf("unexpected models passed to hook: %+v", models)
+		}
+		model := models[0]
+		model.Fields[0].Name = "UserID"
+		model.Fields[0].Tag.Set("json", "userId")
+		model.Fields = model.Fields[:1]
+	}, refs)
+
+	if len(fields) != 1 {
+		t.Fatalf("expected dropped field to be removed, got %d fields", len(fields))
+	}
+	if fields[0].Name != "UserID" || fields[0].Tag["json"] != "userId" {
+		t.Fatalf("expected renamed field with json tag, got %+v", fields[0])
+	}
+}
+
+func TestCheckMaxFieldsWarnsOrFails(t *testing.T) {
+	t.Parallel()
+
+	fields := []gen.Field{
+		newTestField("ID", "id", "int64", nil),
+		newTestField("Name", "name", "string", nil),
+		newTestField("Email", "email", "string", nil),
+	}
+	refs := []modelRef{{TableName: "users", StructName: "User", Fields: &fields}}
+	cfg := config.Config{MaxFields: 2}
+
+	var logs bytes.Buffer
+	logger := slog.New(slog.NewTextHandler(&logs, nil))
+	if err := checkMaxFields(logger, cfg, refs); err != nil {
+		t.Fatalf("expected a warning only, got %v", err)
+	}
+	if !strings.Contains(logs.String(), "exceeds MaxFields") || !strings.Contains(logs.String(), "table=users") {
+		t.Fatalf("expected a MaxFields warning for users, got %q", logs.String())
+	}
+
+	cfg.FailFast = true
+	if err := checkMaxFields(logger, cfg, refs); err == nil || !strings.Contains(err.Error(), "User (users) has 3 fields, more than MaxFields = 2; leave the object out of Objects, add it to ExcludeTables") {
+		t.Fatalf("expected FailFast to turn the warning into an error, got %v", err)
+	}
+
+	cfg.MaxFields = 3
+	if err := checkMaxFields(logger, cfg, refs); err != nil {
+		t.Fatalf("expected a model at the limit to pass, got %v", err)
+	}
+}
+
+func TestApplySensitiveColumnsOverridesJSONTags(t *testing.T) {
+	t.Parallel()
+
+	fields := []gen.Field{
+		newTestField("Email", "email", "string", nil),
+		newTestField("PasswordHash", "password_hash", "string", nil),
+	}
+	applyJSONTagOverrides(fields, map[string]string{"password_hash": "passwordHash"})
+
+	if err := applySensitiveColumns("users", fields, []string{"password_hash"}); err != nil {
+		t.Fatalf("apply sensitive columns: %v", err)
+	}
+	if fields[1].Tag["json"] != "-" {
+		t.Fatalf("expected sensitive column to be hidden from JSON, got %q", fields[1].Tag["json"])
+	}
+
+	err := applySensitiveColumns("users", fields, []string{"pasword_hash"})
+	if err == nil || !strings.Contains(err.Error(), `SensitiveColumns["users"]: column "pasword_hash" not found`) {
+		t.Fatalf("expected unknown sensitive column to be rejected, got %v", err)
+	}
+}
+
+func TestApplyReadOnlyFieldsMarksViewColumns(t *testing.T) {
+	t.Parallel()
+
+	id := newTestField("ID", "id", "int64", nil)
+	name := newTestField("Name", "name", "string", field.GormTag{field.TagKeyGormColumn: {"name"}})
+	fields := []gen.Field{id, name}
+
+	applyReadOnlyFields(fields)
+	if got := name.GORMTag.Build(); got != "column:name;->" {
+		t.Fatalf("expected read-only tag, got %q", got)
+	}
+	if _, ok := id.GORMTag[gormReadOnlyTag]; !ok {
+		t.Fatal("expected a field without gorm tags to become read-only")
+	}
+}
+
+func TestApplySoftDeleteFieldsTypesDeletedAt(t *testing.T) {
+	t.Parallel()
+
+	deletedAt := newTestField("DeletedAt", "deleted_at", "*time.Time", nil)
+	archivedAt := newTestField("ArchivedAt", "archived_at", "*time.Time", nil)
+	deletedFlag := newTestField("Deleted", "deleted_at", "*bool", nil)
+
+	opts := softDeleteOptions{Column: config.DefaultSoftDeleteColumn}
+	applySoftDeleteFields([]gen.Field{deletedAt, archivedAt}, opts, nil)
+	applySoftDeleteFields([]gen.Field{deletedFlag}, opts, nil)
+	if deletedAt.Type != "gorm.DeletedAt" {
+		t.Fatalf("expected deleted_at to become gorm.DeletedAt, got %q", deletedAt.Type)
+	}
+	if archivedAt.Type != "*time.Time" {
+		t.Fatalf("expected other timestamps to be left alone, got %q", archivedAt.Type)
+	}
+	if deletedFlag.Type != "*bool" {
+		t.Fatalf("expected a non-timestamp deleted_at to be left alone, got %q", deletedFlag.Type)
+	}
+}
+
+func TestApplySoftDeleteFieldsHonorsEnableSoftDelete(t *testing.T) {
+	t.Parallel()
+
+	removedAt := newTestField("RemovedAt", "removed_at", "time.Time", nil)
+	deletedAt := newTestField("DeletedAt", "deleted_at", "gorm.DeletedAt", nil)
+	applySoftDeleteFields([]gen.Field{removedAt, deletedAt}, softDeleteOptions{Column: "removed_at", Enabled: true}, nil)
+	if removedAt.Type != "gorm.DeletedAt" || removedAt.GORMTag.Build() != "index" {
+		t.Fatalf("expected removed_at to become an indexed gorm.DeletedAt, got %q `%s`", removedAt.Type, removedAt.GORMTag.Build())
+	}
+	if deletedAt.Type != "time.Time" {
+		t.Fatalf("expected gen's deleted_at conversion to be undone for another soft-delete column, got %q", deletedAt.Type)
+	}
+
+	indexed := newTestField("DeletedAt", "deleted_at", "time.Time", field.GormTag{}.Set(field.TagKeyGormIndex, "idx_tasks_deleted_at"))
+	applySoftDeleteFields([]gen.Field{indexed}, softDeleteOptions{Column: "deleted_at", Enabled: true}, nil)
+	if got := indexed.GORMTag.Build(); got != "index:idx_tasks_deleted_at" {
+		t.Fatalf("expected the introspected index tag to be kept, got %q", got)
+	}
+
+	dateColumn := newTestField("DeletedAt", "deleted_at", "gorm.DeletedAt", nil)
+	isTimestamp := postgresTimestampColumns(map[string]postgresColumnMetadata{"deleted_at": {ColumnName: "deleted_at", DataType: "date"}})
+	applySoftDeleteFields([]gen.Field{dateColumn}, softDeleteOptions{Column: "deleted_at", Enabled: true}, isTimestamp)
+	if dateColumn.Type != "time.Time" {
+		t.Fatalf("expected a date column not to become gorm.DeletedAt, got %q", dateColumn.Type)
+	}
+
+	disabled := newTestField("DeletedAt", "deleted_at", "gorm.DeletedAt", nil)
+	applySoftDeleteFields([]gen.Field{disabled}, softDeleteOptions{}, nil)
+	if disabled.Type != "time.Time" {
+		t.Fatalf("expected EnableSoftDelete = false to leave deleted_at a timestamp, got %q", disabled.Type)
+	}
+}
+
+func TestApplyColumnTypeOverridesKeepsNullability(t *testing.T) {
+	t.Parallel()
+
+	subtotal := newTestField("Subtotal", "subtotal", "string", nil)
+	tax := newTestField("Tax", "tax", "*string", nil)
+	discount := newTestField("Discount", "discount", "string", nil)
+	rate := newTestField("Rate", "rate", "string", nil)
+
+	applyColumnTypeOverrides([]gen.Field{subtotal, tax, discount, rate}, map[string]string{
+		"subtotal": "decimal.Decimal",
+		"tax":      "decimal.Decimal",
+		"discount": "*decimal.Decimal",
+		"missing":  "decimal.Decimal",
+	})
+	if subtotal.Type != "decimal.Decimal" {
+		t.Fatalf("expected subtotal to be overridden, got %q", subtotal.Type)
+	}
+	if tax.Type != "*decimal.Decimal" {
+		t.Fatalf("expected nullable tax to stay a pointer, got %q", tax.Type)
+	}
+	if discount.Type != "*decimal.Decimal" {
+		t.Fatalf("expected an explicit pointer override, got %q", discount.Type)
+	}
+	if rate.Type != "string" {
+		t.Fatalf("expected columns without an override to be left alone, got %q", rate.Type)
+	}
+}
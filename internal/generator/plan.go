@@ -0,0 +1,178 @@
+package generator
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/dan-sherwin/gormdb2struct/internal/config"
+	"github.com/dan-sherwin/gormdb2struct/sqlitetype"
+)
+
+// GenerationPlan describes what Generate would produce for a config, resolved
+// against the live database.
+type GenerationPlan struct {
+	Dialect config.DatabaseDialect
+	OutPath string
+	// CleanUpPath is the directory whose *gen.go files CleanUp would delete,
+	// or "" when CleanUp is off.
+	CleanUpPath string
+	Objects     []PlannedObject
+}
+
+// PlannedObject is one table or view and the per-object settings that would
+// apply to its model.
+type PlannedObject struct {
+	Name       string
+	Kind       string
+	StructName string
+	// FileName is the model file base name from ModelFileNamePattern, or ""
+	// for gen's default.
+	FileName            string
+	ExtraFields         []string
+	JSONTagOverrides    []string
+	ColumnTypeOverrides []string
+	SensitiveColumns    []string
+}
+
+// Plan connects to the database and resolves the objects Generate would
+// model, without cleaning up, running gen, or writing any file.
+func (s *Service) Plan(ctx context.Context, cfg config.Config) (GenerationPlan, error) {
+	if err := ctx.Err(); err != nil {
+		return GenerationPlan{}, err
+	}
+
+	plan := GenerationPlan{Dialect: cfg.DatabaseDialect, OutPath: cfg.OutPath}
+	if cfg.CleanUp {
+		plan.CleanUpPath = cleanUpPath(cfg)
+	}
+
+	switch cfg.DatabaseDialect {
+	case config.PostgreSQL:
+		db, err := openPostgresDB(ctx, s.logger, cfg)
+		if err != nil {
+			return GenerationPlan{}, err
+		}
+		objects, err := postgresObjects(db, cfg)
+		if err != nil {
+			return GenerationPlan{}, err
+		}
+		for _, object := range excludePostgresObjects(s.logger, cfg, objects) {
+			planned, err := newPlannedObject(cfg, object.Name, string(object.Kind))
+			if err != nil {
+				return GenerationPlan{}, err
+			}
+			plan.Objects = append(plan.Objects, planned)
+		}
+	case config.SQLite:
+		db, err := openSQLiteDBReadOnly(ctx, s.logger, cfg)
+		if err != nil {
+			return GenerationPlan{}, err
+		}
+		names, err := sqliteObjectNames(s.logger, db, cfg)
+		if err != nil {
+			return GenerationPlan{}, err
+		}
+		existing, err := sqliteSchemaObjectNames(db)
+		if err != nil {
+			return GenerationPlan{}, err
+		}
+		viewNames, err := sqlitetype.LoadViewNames(db)
+		if err != nil {
+			return GenerationPlan{}, err
+		}
+		views := make(map[string]struct{}, len(viewNames))
+		for _, viewName := range viewNames {
+			views[viewName] = struct{}{}
+		}
+		for _, name := range names {
+			if _, exists := existing[name]; !exists {
+				return GenerationPlan{}, fmt.Errorf("SQLite object %q was not found in %s", name, cfg.SQLiteDBPath)
+			}
+			kind := "table"
+			if _, isView := views[name]; isView {
+				kind = "view"
+			}
+			planned, err := newPlannedObject(cfg, name, kind)
+			if err != nil {
+				return GenerationPlan{}, err
+			}
+			plan.Objects = append(plan.Objects, planned)
+		}
+	default:
+		return GenerationPlan{}, fmt.Errorf("unsupported database dialect %q", cfg.DatabaseDialect)
+	}
+
+	objectNames := make([]string, 0, len(plan.Objects))
+	for _, object := range plan.Objects {
+		objectNames = append(objectNames, object.Name)
+	}
+	if err := checkStructNameCollisions(cfg, objectNames); err != nil {
+		return GenerationPlan{}, err
+	}
+	return plan, nil
+}
+
+func newPlannedObject(cfg config.Config, name, kind string) (PlannedObject, error) {
+	structName := cfg.ModelStructName(name)
+	fileName, err := cfg.ModelFileName(name, structName)
+	if err != nil {
+		return PlannedObject{}, err
+	}
+
+	planned := PlannedObject{
+		Name:             name,
+		Kind:             kind,
+		StructName:       structName,
+		FileName:         fileName,
+		JSONTagOverrides: sortedKeys(cfg.JSONTagOverridesByTable[name]),
+		SensitiveColumns: append([]string(nil), cfg.SensitiveColumns[name]...),
+	}
+	for _, extraField := range cfg.ExtraFields[name] {
+		planned.ExtraFields = append(planned.ExtraFields, extraField.StructPropName)
+	}
+	overrides := cfg.ColumnTypeOverridesByTable[name]
+	for _, column := range sortedKeys(overrides) {
+		planned.ColumnTypeOverrides = append(planned.ColumnTypeOverrides, column+"="+strings.TrimSpace(overrides[column]))
+	}
+	return planned, nil
+}
+
+func sortedKeys(values map[string]string) []string {
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// RenderGenerationPlan formats a plan for the --dry-run output.
+func RenderGenerationPlan(plan GenerationPlan) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "Dry run for %s (%s): nothing is written\n", plan.OutPath, plan.Dialect)
+	if plan.CleanUpPath != "" {
+		fmt.Fprintf(&b, "CleanUp would remove the *gen.go files under %s\n", plan.CleanUpPath)
+	}
+	fmt.Fprintf(&b, "Objects (%d):\n", len(plan.Objects))
+	for _, object := range plan.Objects {
+		fmt.Fprintf(&b, "  %s (%s) -> %s", object.Name, object.Kind, object.StructName)
+		if object.FileName != "" {
+			fmt.Fprintf(&b, " in %s.gen.go", object.FileName)
+		}
+		b.WriteString("\n")
+		writePlanList(&b, "ExtraFields", object.ExtraFields)
+		writePlanList(&b, "JSONTagOverridesByTable", object.JSONTagOverrides)
+		writePlanList(&b, "ColumnTypeOverridesByTable", object.ColumnTypeOverrides)
+		writePlanList(&b, "SensitiveColumns", object.SensitiveColumns)
+	}
+	return b.String()
+}
+
+func writePlanList(b *strings.Builder, label string, values []string) {
+	if len(values) == 0 {
+		return
+	}
+	fmt.Fprintf(b, "    %s: %s\n", label, strings.Join(values, ", "))
+}
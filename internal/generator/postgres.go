@@ -4,11 +4,11 @@ import (
 	"context"
 	"database/sql"
 	"fmt"
+	"log/slog"
 	"strings"
 
 	"github.com/dan-sherwin/gormdb2struct/internal/config"
 	"github.com/dan-sherwin/gormdb2struct/pgtypes"
-	"gorm.io/gen"
 	"gorm.io/gorm"
 )
 
@@ -40,100 +40,168 @@ func (s *Service) generatePostgres(ctx context.Context, cfg config.Config) error
 		return fmt.Errorf("get PostgreSQL sql.DB handle: %w", err)
 	}
 
+	objects, err := postgresObjects(db, cfg)
+	if err != nil {
+		return err
+	}
+	objects = excludePostgresObjects(s.logger, cfg, objects)
+	objectNames := make([]string, 0, len(objects))
+	for _, object := range objects {
+		objectNames = append(objectNames, object.Name)
+	}
+	if err := checkStructNameCollisions(cfg, objectNames); err != nil {
+		return err
+	}
+
 	if cfg.CleanUp {
-		if err := cleanUp(cfg.OutPath); err != nil {
+		if err := cleanUp(cleanUpPath(cfg)); err != nil {
 			return err
 		}
 	}
+	if err := prepareOutDirs(cfg); err != nil {
+		return err
+	}
 
-	effectiveCfg, err := preparePostgresGeneratedTypes(cfg, db)
+	effectiveCfg, checkEnumTypes, err := preparePostgresGeneratedTypes(cfg, db, objects)
 	if err != nil {
 		return err
 	}
 
-	g := newGenerator(effectiveCfg.OutPath)
-	configureJSONTags(g)
+	g := newGenerator(effectiveCfg)
+	configureJSONTags(g, effectiveCfg.JSONTagStrategy)
 	g.WithImportPkgPath(effectiveCfg.ImportPackagePaths...)
 	g.WithDataTypeMap(buildPostgresDataTypeMap(effectiveCfg))
 	g.UseDB(db)
 
-	objects, err := postgresObjects(db, effectiveCfg)
+	columnMeta, err := loadPostgresColumnMetadata(db, cfg.CatalogSource, postgresObjectNames(objects, postgresObjectTable))
 	if err != nil {
 		return err
 	}
 
 	models := make([]any, 0, len(objects))
+	refs := make([]modelRef, 0, len(objects))
+	auditTables := make([]postgresAuditTable, 0, len(objects))
+	var matviews []postgresMatview
+	fileNames := map[string]string{}
+	softDelete := newSoftDeleteOptions(effectiveCfg)
 	for _, object := range objects {
 		switch object.Kind {
 		case postgresObjectTable:
-			model := g.GenerateModel(object.Name)
-			if extraFields, ok := effectiveCfg.ExtraFields[object.Name]; ok {
-				for _, extraField := range extraFields {
-					fieldFactory := gen.FieldNew("", "", nil)
-					fld := fieldFactory(nil)
-					genRelationField(extraField, gen.Field(fld))
-					model.Fields = append(model.Fields, fld)
-				}
+			model := g.GenerateModelAs(object.Name, effectiveCfg.ModelStructName(object.Name))
+			applyPostgresAutoIncrement(model.Fields, columnMeta[object.Name])
+			applySoftDeleteFields(model.Fields, softDelete, postgresTimestampColumns(columnMeta[object.Name]))
+			if err := applyPostgresFieldTypes(effectiveCfg, object.Name, model.Fields, checkEnumTypes[object.Name], columnMeta[object.Name]); err != nil {
+				return err
 			}
-			if jsonOverrides, ok := effectiveCfg.JSONTagOverridesByTable[object.Name]; ok {
-				for _, fld := range model.Fields {
-					if jsonTag, exists := jsonOverrides[fld.ColumnName]; exists {
-						fld.Tag.Set("json", jsonTag)
-						continue
-					}
-					if jsonTag, exists := jsonOverrides[fld.Name]; exists {
-						fld.Tag.Set("json", jsonTag)
-					}
-				}
+			auditTables = append(auditTables, newPostgresAuditTable(object.Name, model.Fields))
+			appendExtraFields(&model.Fields, effectiveCfg.ExtraFields[object.Name], effectiveCfg.JSONTagStrategy)
+			applyJSONTagOverrides(model.Fields, effectiveCfg.JSONTagOverridesByTable[object.Name])
+			if err := applySensitiveColumns(object.Name, model.Fields, effectiveCfg.SensitiveColumns[object.Name]); err != nil {
+				return err
 			}
-			models = append(models, model)
-		case postgresObjectView, postgresObjectMaterializedView:
-			tmpViewName := object.Name + "_temp"
-			if err := createTempView(sqldb, tmpViewName, object.Name); err != nil {
+			if err := applyModelFileName(effectiveCfg, &model.FileName, object.Name, model.ModelStructName, fileNames); err != nil {
 				return err
 			}
-			defer func(name string) {
-				_ = dropView(sqldb, name)
-			}(tmpViewName)
-
-			model := g.GenerateModelAs(tmpViewName, effectiveCfg.NamingStrategy.SchemaName(object.Name))
-			if extraFields, ok := effectiveCfg.ExtraFields[object.Name]; ok {
-				for _, extraField := range extraFields {
-					fieldFactory := gen.FieldNew("", "", nil)
-					fld := fieldFactory(nil)
-					genRelationField(extraField, gen.Field(fld))
-					model.Fields = append(model.Fields, fld)
+			models = append(models, model)
+			refs = append(refs, modelRef{TableName: object.Name, StructName: model.ModelStructName, Fields: &model.Fields})
+		case postgresObjectView, postgresObjectMaterializedView:
+			// gen reads columns from information_schema.columns, which lists
+			// plain views but not materialized views, so only the latter are
+			// read through a temporary plain view over them.
+			sourceName := object.Name
+			if object.Kind == postgresObjectMaterializedView {
+				sourceName = object.Name + "_temp"
+				if err := createTempView(sqldb, sourceName, object.Name); err != nil {
+					return err
 				}
+				defer func(name string) {
+					_ = dropView(sqldb, name)
+				}(sourceName)
 			}
+
+			model := g.GenerateModelAs(sourceName, effectiveCfg.ModelStructName(object.Name))
+			applyColumnTypeOverrides(model.Fields, effectiveCfg.ColumnTypeOverridesByTable[object.Name])
+			applyReadOnlyFields(model.Fields)
+			appendExtraFields(&model.Fields, effectiveCfg.ExtraFields[object.Name], effectiveCfg.JSONTagStrategy)
 			model.FileName = object.Name
 			model.TableName = object.Name
-			if jsonOverrides, ok := effectiveCfg.JSONTagOverridesByTable[object.Name]; ok {
-				for _, fld := range model.Fields {
-					if jsonTag, exists := jsonOverrides[fld.ColumnName]; exists {
-						fld.Tag.Set("json", jsonTag)
-						continue
-					}
-					if jsonTag, exists := jsonOverrides[fld.Name]; exists {
-						fld.Tag.Set("json", jsonTag)
-					}
-				}
+			applyJSONTagOverrides(model.Fields, effectiveCfg.JSONTagOverridesByTable[object.Name])
+			if err := applySensitiveColumns(object.Name, model.Fields, effectiveCfg.SensitiveColumns[object.Name]); err != nil {
+				return err
+			}
+			if err := applyModelFileName(effectiveCfg, &model.FileName, object.Name, model.ModelStructName, fileNames); err != nil {
+				return err
 			}
 			models = append(models, model)
+			refs = append(refs, modelRef{
+				TableName:        object.Name,
+				StructName:       model.ModelStructName,
+				View:             true,
+				MaterializedView: object.Kind == postgresObjectMaterializedView,
+				Fields:           &model.Fields,
+			})
+			if object.Kind == postgresObjectMaterializedView {
+				matviews = append(matviews, postgresMatview{StructName: model.ModelStructName, Name: object.Name})
+			}
 		default:
 			return fmt.Errorf("unsupported PostgreSQL object kind %q for %q", object.Kind, object.Name)
 		}
 	}
 
-	g.ApplyBasic(models...)
-	g.Execute()
+	var foreignKeys []foreignKey
+	if needsForeignKeys(effectiveCfg) {
+		if foreignKeys, err = loadPostgresForeignKeys(db); err != nil {
+			return err
+		}
+	}
+	if effectiveCfg.DetectForeignKeys {
+		for _, skipped := range applyDetectedRelations(effectiveCfg, g, refs, foreignKeys) {
+			s.logger.Info("Skipping detected relation", "relation", skipped)
+		}
+	}
+
+	applyTransformModels(effectiveCfg.TransformModels, refs)
+	if err := checkMaxFields(s.logger, effectiveCfg, refs); err != nil {
+		return err
+	}
+	if err := validateTypeMapTypes(ctx, s.logger, effectiveCfg, refs); err != nil {
+		return err
+	}
+	if err := applyModels(g, db, effectiveCfg, models); err != nil {
+		return err
+	}
+	if err := executeGenerator(g, db); err != nil {
+		return err
+	}
+	if err := applyQueryInterfaceTables(g, effectiveCfg, db, refs); err != nil {
+		return err
+	}
+
+	if err := writeHelperFiles(s.logger, effectiveCfg, g, refs, foreignKeys); err != nil {
+		return err
+	}
+
+	if effectiveCfg.GenerateMatviewRefresh {
+		if err := writePostgresMatviewRefresh(g, matviews); err != nil {
+			return err
+		}
+	}
+
+	if effectiveCfg.GenerateAuditTriggers {
+		if err := writePostgresAuditTriggers(effectiveCfg.OutPath, auditTables); err != nil {
+			return err
+		}
+	}
 
-	if effectiveCfg.DbInit.Enabled {
-		if err := WritePostgresDBInit(effectiveCfg, g); err != nil {
+	if effectiveCfg.DbInit.Enabled && effectiveCfg.ModelsOnly {
+		s.logger.Info("Skipping DbInit because ModelsOnly is set")
+	} else if effectiveCfg.DbInit.Enabled {
+		if err := WritePostgresDBInit(effectiveCfg, g, viewStructNames(refs)); err != nil {
 			return err
 		}
 	}
 
-	return nil
+	return formatGeneratedFiles(effectiveCfg.OutPath)
 }
 
 func postgresObjects(db *gorm.DB, cfg config.Config) ([]postgresObject, error) {
@@ -152,6 +220,33 @@ func postgresObjects(db *gorm.DB, cfg config.Config) ([]postgresObject, error) {
 	return resolveConfiguredPostgresObjects(*cfg.Objects, relations, routines)
 }
 
+// excludePostgresObjects drops the tables and views matched by ExcludeTables
+// and the materialized views matched by ExcludeMaterializedViews.
+func excludePostgresObjects(logger *slog.Logger, cfg config.Config, objects []postgresObject) []postgresObject {
+	kept := make([]postgresObject, 0, len(objects))
+	for _, object := range objects {
+		option, patterns := "ExcludeTables", cfg.ExcludeTables
+		if object.Kind == postgresObjectMaterializedView {
+			option, patterns = "ExcludeMaterializedViews", cfg.ExcludeMaterializedViews
+		}
+		if excludeObject(logger, cfg, option, patterns, object.Name) {
+			continue
+		}
+		kept = append(kept, object)
+	}
+	return kept
+}
+
+func postgresObjectNames(objects []postgresObject, kind postgresObjectKind) []string {
+	names := make([]string, 0, len(objects))
+	for _, object := range objects {
+		if object.Kind == kind {
+			names = append(names, object.Name)
+		}
+	}
+	return names
+}
+
 func loadPostgresRelations(db *gorm.DB) ([]postgresObject, error) {
 	type relationRow struct {
 		Name string
@@ -303,6 +398,12 @@ func buildPostgresDataTypeMap(cfg config.Config) map[string]func(gorm.ColumnType
 	for pgType, goType := range pgtypes.PgTypeMap {
 		dataTypeMap[pgType] = resolver(goType)
 	}
+	switch cfg.MoneyType {
+	case config.MoneyTypeInt64Cents:
+		dataTypeMap["money"] = resolver("pgtypes.Money")
+	case config.MoneyTypeDecimal:
+		dataTypeMap["money"] = resolver("pgtypes.MoneyDecimal")
+	}
 	for pgType, goType := range cfg.TypeMap {
 		dataTypeMap[pgType] = resolver(goType)
 	}
@@ -356,11 +457,7 @@ func postgresDSN(cfg config.Config) string {
 	if cfg.DbPassword != "" {
 		parts = append(parts, fmt.Sprintf("password=%s", cfg.DbPassword))
 	}
-	if cfg.DbSSLMode {
-		parts = append(parts, "sslmode=require")
-	} else {
-		parts = append(parts, "sslmode=disable")
-	}
+	parts = append(parts, "sslmode="+cfg.PostgresSSLMode())
 
 	return strings.Join(parts, " ")
 }
@@ -0,0 +1,132 @@
+package generator
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"gorm.io/gen"
+	"gorm.io/gen/field"
+)
+
+const (
+	postgresAuditTableName    = "gormdb2struct_audit_log"
+	postgresAuditFunctionName = "gormdb2struct_audit_row_change"
+)
+
+type postgresAuditTable struct {
+	Name       string
+	KeyColumns []string
+}
+
+// newPostgresAuditTable records the primary key columns of a generated table
+// so each audit row can be traced back to the row it describes.
+func newPostgresAuditTable(tableName string, fields []gen.Field) postgresAuditTable {
+	table := postgresAuditTable{Name: tableName}
+	for _, fld := range fields {
+		if fld == nil || fld.GORMTag == nil || fld.ColumnName == "" {
+			continue
+		}
+		if _, isPrimaryKey := fld.GORMTag[field.TagKeyGormPrimaryKey]; isPrimaryKey {
+			table.KeyColumns = append(table.KeyColumns, fld.ColumnName)
+		}
+	}
+	return table
+}
+
+// writePostgresAuditTriggers emits audit_gen.sql, an idempotent installer for a
+// shared audit table, one trigger function, and a trigger per generated table.
+func writePostgresAuditTriggers(outPath string, tables []postgresAuditTable) error {
+	type triggerTarget struct {
+		Table     string
+		Arguments string
+	}
+
+	data := struct {
+		AuditTable    string
+		AuditFunction string
+		Targets       []triggerTarget
+	}{
+		AuditTable:    quoteIdent(postgresAuditTableName),
+		AuditFunction: quoteIdent(postgresAuditFunctionName),
+	}
+	for _, table := range tables {
+		if table.Name == postgresAuditTableName {
+			continue
+		}
+		arguments := make([]string, 0, len(table.KeyColumns))
+		for _, column := range table.KeyColumns {
+			arguments = append(arguments, quoteLiteral(column))
+		}
+		data.Targets = append(data.Targets, triggerTarget{
+			Table:     quoteIdent(table.Name),
+			Arguments: strings.Join(arguments, ", "),
+		})
+	}
+
+	rendered, err := renderTemplate("postgres_audit_triggers", postgresAuditTriggersTemplate, data)
+	if err != nil {
+		return err
+	}
+
+	outFile := filepath.Join(outPath, "audit_gen.sql")
+	if err := os.WriteFile(outFile, rendered, 0o644); err != nil {
+		return fmt.Errorf("write audit trigger file %s: %w", outFile, err)
+	}
+	return nil
+}
+
+func quoteLiteral(value string) string {
+	return "'" + strings.ReplaceAll(value, "'", "''") + "'"
+}
+
+const postgresAuditTriggersTemplate = `-- Code generated by gormdb2struct; DO NOT EDIT.
+-- Installs append-only row auditing for the generated tables. Safe to re-run.
+
+CREATE TABLE IF NOT EXISTS {{.AuditTable}} (
+	id bigserial PRIMARY KEY,
+	table_name text NOT NULL,
+	operation text NOT NULL,
+	row_key jsonb NOT NULL DEFAULT '{}'::jsonb,
+	old_data jsonb,
+	new_data jsonb,
+	changed_by text NOT NULL DEFAULT current_user,
+	changed_at timestamptz NOT NULL DEFAULT now()
+);
+
+-- Trigger arguments name the primary key columns copied into row_key.
+CREATE OR REPLACE FUNCTION {{.AuditFunction}}() RETURNS trigger
+LANGUAGE plpgsql AS $$
+DECLARE
+	row_data jsonb;
+	key_data jsonb := '{}'::jsonb;
+	i integer;
+BEGIN
+	IF TG_OP = 'DELETE' THEN
+		row_data := to_jsonb(OLD);
+	ELSE
+		row_data := to_jsonb(NEW);
+	END IF;
+
+	FOR i IN 0 .. TG_NARGS - 1 LOOP
+		key_data := key_data || jsonb_build_object(TG_ARGV[i], row_data -> TG_ARGV[i]);
+	END LOOP;
+
+	INSERT INTO {{.AuditTable}} (table_name, operation, row_key, old_data, new_data)
+	VALUES (
+		TG_TABLE_NAME,
+		TG_OP,
+		key_data,
+		CASE WHEN TG_OP IN ('UPDATE', 'DELETE') THEN to_jsonb(OLD) END,
+		CASE WHEN TG_OP IN ('INSERT', 'UPDATE') THEN to_jsonb(NEW) END
+	);
+	RETURN NULL;
+END;
+$$;
+{{range .Targets}}
+DROP TRIGGER IF EXISTS {{$.AuditFunction}} ON {{.Table}};
+CREATE TRIGGER {{$.AuditFunction}}
+	AFTER INSERT OR UPDATE OR DELETE ON {{.Table}}
+	FOR EACH ROW EXECUTE FUNCTION {{$.AuditFunction}}({{.Arguments}});
+{{end}}`
@@ -0,0 +1,33 @@
+package generator
+
+import (
+	"path/filepath"
+	"testing"
+
+	"gorm.io/gen"
+	"gorm.io/gen/field"
+)
+
+func TestWritePostgresAuditTriggersEmitsIdempotentInstaller(t *testing.T) {
+	t.Parallel()
+
+	outPath := t.TempDir()
+	tables := []postgresAuditTable{
+		newPostgresAuditTable("orders", []gen.Field{
+			newTestField("ID", "id", "int64", field.GormTag{field.TagKeyGormPrimaryKey: nil}),
+			newTestField("Total", "total", "float64", field.GormTag{}),
+		}),
+		newPostgresAuditTable(postgresAuditTableName, nil),
+	}
+
+	if err := writePostgresAuditTriggers(outPath, tables); err != nil {
+		t.Fatalf("write audit triggers: %v", err)
+	}
+
+	outFile := filepath.Join(outPath, "audit_gen.sql")
+	assertFileContains(t, outFile, `CREATE TABLE IF NOT EXISTS "gormdb2struct_audit_log" (`)
+	assertFileContains(t, outFile, `CREATE OR REPLACE FUNCTION "gormdb2struct_audit_row_change"() RETURNS trigger`)
+	assertFileContains(t, outFile, `DROP TRIGGER IF EXISTS "gormdb2struct_audit_row_change" ON "orders";`)
+	assertFileContains(t, outFile, `FOR EACH ROW EXECUTE FUNCTION "gormdb2struct_audit_row_change"('id');`)
+	assertFileNotContains(t, outFile, `ON "gormdb2struct_audit_log"`)
+}
@@ -0,0 +1,216 @@
+package generator
+
+import (
+	"database/sql"
+	"regexp"
+	"strings"
+
+	"github.com/dan-sherwin/gormdb2struct/internal/config"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	"gorm.io/gorm/migrator"
+)
+
+// postgresDialector returns the dialector generation connects with. With
+// CatalogSource = "pg_catalog" it swaps in pgCatalogMigrator, so the column
+// introspection gen runs through Migrator().ColumnTypes never touches
+// information_schema.
+func postgresDialector(cfg config.Config) gorm.Dialector {
+	dialector := postgres.Open(postgresDSN(cfg))
+	if cfg.CatalogSource != config.CatalogSourcePgCatalog {
+		return dialector
+	}
+	return pgCatalogDialector{Dialector: dialector.(*postgres.Dialector)}
+}
+
+type pgCatalogDialector struct {
+	*postgres.Dialector
+}
+
+func (d pgCatalogDialector) Migrator(db *gorm.DB) gorm.Migrator {
+	return pgCatalogMigrator{Migrator: d.Dialector.Migrator(db).(postgres.Migrator)}
+}
+
+// pgCatalogMigrator is the PostgreSQL migrator with ColumnTypes rebuilt on
+// pg_attribute, pg_type, pg_attrdef, and pg_constraint. The result mirrors the
+// driver's information_schema query field for field, so both sources produce
+// the same models. GetIndexes already reads pg_catalog and is inherited.
+type pgCatalogMigrator struct {
+	postgres.Migrator
+}
+
+// pgCatalogColumnsSQL derives the information_schema.columns values the driver
+// reads (udt_name, lengths, precisions, nullability) from the catalogs. Domain
+// columns resolve to their base type the same way information_schema does.
+const pgCatalogColumnsSQL = `
+	SELECT
+		col.column_name,
+		col.nullable,
+		col.udt_name,
+		CASE
+			WHEN col.udt_name IN ('bpchar', 'varchar') AND col.typmod > 0 THEN col.typmod - 4
+			WHEN col.udt_name IN ('bit', 'varbit') AND col.typmod > 0 THEN col.typmod
+		END AS character_maximum_length,
+		CASE col.udt_name
+			WHEN 'int2' THEN 16
+			WHEN 'int4' THEN 32
+			WHEN 'int8' THEN 64
+			WHEN 'float4' THEN 24
+			WHEN 'float8' THEN 53
+			WHEN 'numeric' THEN CASE WHEN col.typmod = -1 THEN NULL ELSE ((col.typmod - 4) >> 16) & 65535 END
+		END AS numeric_precision,
+		CASE
+			WHEN col.udt_name IN ('int2', 'int4', 'int8') THEN 0
+			WHEN col.udt_name = 'numeric' AND col.typmod <> -1 THEN (col.typmod - 4) & 65535
+		END AS numeric_scale,
+		CASE
+			WHEN col.udt_name = 'date' THEN 0
+			WHEN col.udt_name IN ('time', 'timetz', 'timestamp', 'timestamptz') THEN CASE WHEN col.typmod < 0 THEN 6 ELSE col.typmod END
+			WHEN col.udt_name = 'interval' THEN CASE WHEN col.typmod < 0 OR col.typmod & 65535 = 65535 THEN 6 ELSE col.typmod & 65535 END
+		END AS datetime_precision,
+		8 * col.typlen AS type_length,
+		col.column_default,
+		col.description,
+		col.is_identity,
+		col.data_type,
+		col.is_primary,
+		col.is_unique
+	FROM (
+		SELECT
+			a.attnum,
+			a.attname AS column_name,
+			NOT (a.attnotnull OR (t.typtype = 'd' AND t.typnotnull)) AS nullable,
+			bt.typname AS udt_name,
+			bt.typlen,
+			CASE WHEN t.typtype = 'd' THEN t.typtypmod ELSE a.atttypmod END AS typmod,
+			pg_get_expr(ad.adbin, ad.adrelid) AS column_default,
+			d.description,
+			a.attidentity <> '' AS is_identity,
+			format_type(a.atttypid, a.atttypmod) AS data_type,
+			EXISTS (
+				SELECT 1 FROM pg_constraint pk
+				WHERE pk.conrelid = c.oid AND pk.contype = 'p' AND a.attnum = ANY (pk.conkey)
+			) AS is_primary,
+			EXISTS (
+				SELECT 1 FROM pg_constraint uq
+				WHERE uq.conrelid = c.oid AND uq.contype = 'u' AND uq.conkey = ARRAY[a.attnum]
+			) AS is_unique
+		FROM pg_attribute a
+		JOIN pg_class c ON c.oid = a.attrelid
+		JOIN pg_namespace n ON n.oid = c.relnamespace
+		JOIN pg_type t ON t.oid = a.atttypid
+		JOIN pg_type bt ON bt.oid = CASE WHEN t.typtype = 'd' THEN t.typbasetype ELSE t.oid END
+		LEFT JOIN pg_attrdef ad ON ad.adrelid = a.attrelid AND ad.adnum = a.attnum
+		LEFT JOIN pg_description d ON d.objoid = c.oid AND d.objsubid = a.attnum
+		WHERE n.nspname = ?
+		  AND c.relname = ?
+		  AND a.attnum > 0
+		  AND NOT a.attisdropped
+	) col
+	ORDER BY col.attnum
+`
+
+type pgCatalogColumn struct {
+	Name              string
+	Nullable          bool
+	UDTName           string
+	Length            sql.NullInt64
+	Precision         sql.NullInt64
+	Scale             sql.NullInt64
+	DatetimePrecision sql.NullInt64
+	TypeLength        sql.NullInt64
+	Default           sql.NullString
+	Comment           sql.NullString
+	IsIdentity        bool
+	DataType          string
+	IsPrimary         bool
+	IsUnique          bool
+}
+
+var (
+	postgresSerialDefaultPattern = regexp.MustCompile(`^nextval\('"?[^']+seq"?'::regclass\)$`)
+	postgresDefaultCastPattern   = regexp.MustCompile(`^(.*?)(?:::.*)?$`)
+)
+
+// columnType applies the same post-processing as the driver's ColumnTypes.
+func (c pgCatalogColumn) columnType() *migrator.ColumnType {
+	column := &migrator.ColumnType{
+		NameValue:         sql.NullString{String: c.Name, Valid: true},
+		NullableValue:     sql.NullBool{Bool: c.Nullable, Valid: true},
+		DataTypeValue:     sql.NullString{String: c.UDTName, Valid: true},
+		ColumnTypeValue:   sql.NullString{String: c.DataType, Valid: true},
+		LengthValue:       c.Length,
+		DecimalSizeValue:  c.Precision,
+		ScaleValue:        c.Scale,
+		DefaultValueValue: c.Default,
+		CommentValue:      c.Comment,
+		PrimaryKeyValue:   sql.NullBool{Bool: c.IsPrimary, Valid: true},
+		UniqueValue:       sql.NullBool{Bool: c.IsUnique, Valid: true},
+	}
+	if c.TypeLength.Valid && c.TypeLength.Int64 > 0 {
+		column.LengthValue = c.TypeLength
+	}
+	if postgresSerialDefaultPattern.MatchString(c.Default.String) || c.IsIdentity {
+		column.AutoIncrementValue = sql.NullBool{Bool: true, Valid: true}
+		column.DefaultValueValue = sql.NullString{}
+	}
+	if column.DefaultValueValue.Valid {
+		column.DefaultValueValue.String = strings.Trim(postgresDefaultCastPattern.ReplaceAllString(column.DefaultValueValue.String, "$1"), "'")
+	}
+	if c.DatetimePrecision.Valid {
+		column.DecimalSizeValue = c.DatetimePrecision
+	}
+	if strings.HasPrefix(c.UDTName, "_") {
+		column.DataTypeValue = sql.NullString{String: c.DataType, Valid: true}
+	}
+	return column
+}
+
+func (m pgCatalogMigrator) ColumnTypes(value any) ([]gorm.ColumnType, error) {
+	columnTypes := make([]gorm.ColumnType, 0)
+	err := m.RunWithValue(value, func(stmt *gorm.Statement) error {
+		currentSchema, table := m.CurrentSchema(stmt, stmt.Table)
+
+		var columns []pgCatalogColumn
+		rows, err := m.DB.Raw(pgCatalogColumnsSQL, currentSchema, table).Rows()
+		if err != nil {
+			return err
+		}
+		for rows.Next() {
+			var c pgCatalogColumn
+			if err := rows.Scan(
+				&c.Name, &c.Nullable, &c.UDTName, &c.Length, &c.Precision, &c.Scale, &c.DatetimePrecision,
+				&c.TypeLength, &c.Default, &c.Comment, &c.IsIdentity, &c.DataType, &c.IsPrimary, &c.IsUnique,
+			); err != nil {
+				_ = rows.Close()
+				return err
+			}
+			columns = append(columns, c)
+		}
+		if err := rows.Close(); err != nil {
+			return err
+		}
+
+		sampleRows, err := m.GetRows(currentSchema, table)
+		if err != nil {
+			return err
+		}
+		defer sampleRows.Close()
+		rawColumnTypes, err := sampleRows.ColumnTypes()
+		if err != nil {
+			return err
+		}
+		for _, c := range columns {
+			column := c.columnType()
+			for _, raw := range rawColumnTypes {
+				if raw.Name() == c.Name {
+					column.SQLColumnType = raw
+					break
+				}
+			}
+			columnTypes = append(columnTypes, column)
+		}
+		return nil
+	})
+	return columnTypes, err
+}
@@ -0,0 +1,81 @@
+package generator
+
+import (
+	"database/sql"
+	"testing"
+
+	"github.com/dan-sherwin/gormdb2struct/internal/config"
+)
+
+func TestPostgresDialectorUsesPgCatalogMigratorOnlyWhenSelected(t *testing.T) {
+	t.Parallel()
+
+	cfg := config.Config{DbHost: "localhost", DbPort: 5432, DbName: "app"}
+	if _, ok := postgresDialector(cfg).(pgCatalogDialector); ok {
+		t.Fatal("expected the default dialector to keep the driver's information_schema introspection")
+	}
+
+	cfg.CatalogSource = config.CatalogSourcePgCatalog
+	dialector, ok := postgresDialector(cfg).(pgCatalogDialector)
+	if !ok {
+		t.Fatalf("expected pgCatalogDialector for CatalogSource = pg_catalog, got %T", postgresDialector(cfg))
+	}
+	if _, ok := dialector.Migrator(nil).(pgCatalogMigrator); !ok {
+		t.Fatalf("expected pgCatalogMigrator, got %T", dialector.Migrator(nil))
+	}
+}
+
+func TestPgCatalogColumnTypeMatchesDriverPostProcessing(t *testing.T) {
+	t.Parallel()
+
+	serial := pgCatalogColumn{
+		Name:       "id",
+		UDTName:    "int8",
+		TypeLength: sql.NullInt64{Int64: 64, Valid: true},
+		Default:    sql.NullString{String: "nextval('orders_id_seq'::regclass)", Valid: true},
+		DataType:   "bigint",
+		IsPrimary:  true,
+	}.columnType()
+	if autoIncrement, _ := serial.AutoIncrement(); !autoIncrement {
+		t.Fatal("expected a nextval default to mark the column auto-increment")
+	}
+	if _, hasDefault := serial.DefaultValue(); hasDefault {
+		t.Fatal("expected the sequence default to be dropped")
+	}
+	if primaryKey, _ := serial.PrimaryKey(); !primaryKey {
+		t.Fatal("expected the primary key flag to carry over")
+	}
+	if length, _ := serial.Length(); length != 64 {
+		t.Fatalf("expected the type length in bits, got %d", length)
+	}
+
+	status := pgCatalogColumn{
+		Name:     "status",
+		Nullable: true,
+		UDTName:  "varchar",
+		Length:   sql.NullInt64{Int64: 20, Valid: true},
+		Default:  sql.NullString{String: "'new'::character varying", Valid: true},
+		DataType: "character varying(20)",
+	}.columnType()
+	if value, _ := status.DefaultValue(); value != "new" {
+		t.Fatalf("expected the cast and quotes to be stripped from the default, got %q", value)
+	}
+	if columnType, _ := status.ColumnType(); columnType != "character varying(20)" {
+		t.Fatalf("unexpected column type %q", columnType)
+	}
+
+	tags := pgCatalogColumn{Name: "tags", UDTName: "_text", DataType: "text[]"}.columnType()
+	if tags.DatabaseTypeName() != "text[]" {
+		t.Fatalf("expected array columns to report the formatted type, got %q", tags.DatabaseTypeName())
+	}
+
+	createdAt := pgCatalogColumn{
+		Name:              "created_at",
+		UDTName:           "timestamptz",
+		DatetimePrecision: sql.NullInt64{Int64: 6, Valid: true},
+		DataType:          "timestamp with time zone",
+	}.columnType()
+	if precision, _, _ := createdAt.DecimalSize(); precision != 6 {
+		t.Fatalf("expected the datetime precision as decimal size, got %d", precision)
+	}
+}
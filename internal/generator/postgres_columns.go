@@ -0,0 +1,208 @@
+package generator
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/dan-sherwin/gormdb2struct/internal/config"
+	"gorm.io/gen"
+	"gorm.io/gen/field"
+	"gorm.io/gorm"
+)
+
+type postgresColumnMetadata struct {
+	TableName   string `gorm:"column:table_name"`
+	ColumnName  string `gorm:"column:column_name"`
+	HasSequence bool   `gorm:"column:has_sequence"`
+	IsNullable  bool   `gorm:"column:is_nullable"`
+	DataType    string `gorm:"column:data_type"`
+}
+
+const informationSchemaColumnMetadataSQL = `
+	SELECT
+		c.table_name AS table_name,
+		c.column_name AS column_name,
+		(
+			c.is_identity = 'YES'
+			OR pg_get_serial_sequence(format('%I.%I', c.table_schema, c.table_name), c.column_name) IS NOT NULL
+			OR COALESCE(c.column_default, '') LIKE 'nextval(%'
+		) AS has_sequence,
+		c.is_nullable = 'YES' AS is_nullable,
+		c.udt_name AS data_type
+	FROM information_schema.columns c
+	WHERE c.table_schema = 'public'
+	  AND c.table_name IN ?
+	ORDER BY c.table_name, c.ordinal_position
+`
+
+const pgCatalogColumnMetadataSQL = `
+	SELECT
+		cl.relname AS table_name,
+		a.attname AS column_name,
+		(
+			a.attidentity <> ''
+			OR pg_get_serial_sequence(format('%I.%I', n.nspname, cl.relname), a.attname) IS NOT NULL
+			OR COALESCE(pg_get_expr(ad.adbin, ad.adrelid), '') LIKE 'nextval(%'
+		) AS has_sequence,
+		NOT (a.attnotnull OR (t.typtype = 'd' AND t.typnotnull)) AS is_nullable,
+		bt.typname AS data_type
+	FROM pg_attribute a
+	JOIN pg_class cl ON cl.oid = a.attrelid
+	JOIN pg_namespace n ON n.oid = cl.relnamespace
+	JOIN pg_type t ON t.oid = a.atttypid
+	JOIN pg_type bt ON bt.oid = CASE WHEN t.typtype = 'd' THEN t.typbasetype ELSE t.oid END
+	LEFT JOIN pg_attrdef ad ON ad.adrelid = a.attrelid AND ad.adnum = a.attnum
+	WHERE n.nspname = 'public'
+	  AND cl.relname IN ?
+	  AND a.attnum > 0
+	  AND NOT a.attisdropped
+	ORDER BY cl.relname, a.attnum
+`
+
+// loadPostgresColumnMetadata reads per-column facts that gorm's column types do
+// not expose reliably, keyed by table name and then column name.
+func loadPostgresColumnMetadata(db *gorm.DB, source config.CatalogSource, tableNames []string) (map[string]map[string]postgresColumnMetadata, error) {
+	out := make(map[string]map[string]postgresColumnMetadata, len(tableNames))
+	if len(tableNames) == 0 {
+		return out, nil
+	}
+
+	query := informationSchemaColumnMetadataSQL
+	if source == config.CatalogSourcePgCatalog {
+		query = pgCatalogColumnMetadataSQL
+	}
+	var rows []postgresColumnMetadata
+	if err := db.Raw(query, tableNames).Scan(&rows).Error; err != nil {
+		return nil, fmt.Errorf("load PostgreSQL column metadata: %w", err)
+	}
+
+	for _, row := range rows {
+		columns := out[row.TableName]
+		if columns == nil {
+			columns = map[string]postgresColumnMetadata{}
+			out[row.TableName] = columns
+		}
+		columns[row.ColumnName] = row
+	}
+
+	return out, nil
+}
+
+// applyPostgresAutoIncrement makes the autoIncrement tag of integer primary keys
+// explicit. GORM assumes integer primary keys auto-increment, so application
+// assigned IDs are silently dropped on insert unless the tag says otherwise.
+func applyPostgresAutoIncrement(fields []gen.Field, columns map[string]postgresColumnMetadata) {
+	for _, fld := range fields {
+		if fld == nil || fld.GORMTag == nil {
+			continue
+		}
+		if _, isPrimaryKey := fld.GORMTag[field.TagKeyGormPrimaryKey]; !isPrimaryKey {
+			continue
+		}
+		if !isIntegerGoType(fld.Type) {
+			continue
+		}
+		meta, ok := columns[fld.ColumnName]
+		if !ok {
+			continue
+		}
+		fld.GORMTag.Set(field.TagKeyGormAutoIncrement, fmt.Sprintf("%t", meta.HasSequence))
+	}
+}
+
+// postgresTimestampColumns reports whether a column is a timestamp or
+// timestamptz; columns without metadata are assumed to be.
+func postgresTimestampColumns(columns map[string]postgresColumnMetadata) func(column string) bool {
+	return func(column string) bool {
+		meta, ok := columns[column]
+		if !ok {
+			return true
+		}
+		switch meta.DataType {
+		case "timestamp", "timestamptz":
+			return true
+		default:
+			return false
+		}
+	}
+}
+
+// applyPostgresNullability makes nullable columns pointers again after their
+// type was replaced. With strict set (StrictNullability), pointer-ness follows
+// the declared nullability exactly: gen also turns NOT NULL columns with a
+// default into pointers, so the same schema could yield different field types
+// depending on defaults. NOT NULL columns keep their default tag, so GORM
+// still inserts the database default for a zero value.
+func applyPostgresNullability(fields []gen.Field, columns map[string]postgresColumnMetadata, strict bool) {
+	for _, fld := range fields {
+		if fld == nil || fld.Type == "gorm.DeletedAt" {
+			continue
+		}
+		meta, ok := columns[fld.ColumnName]
+		if !ok {
+			continue
+		}
+		isPointer := strings.HasPrefix(fld.Type, "*")
+		switch {
+		case meta.IsNullable && !isPointer:
+			fld.Type = "*" + fld.Type
+		case strict && !meta.IsNullable && isPointer:
+			fld.Type = strings.TrimPrefix(fld.Type, "*")
+		}
+	}
+}
+
+// applyPostgresFieldTypes settles the Go type of each table column. The
+// ColumnTypeOverridesByTable types go last, after nullability, so an override
+// to a pointer type on a NOT NULL column is kept as written.
+func applyPostgresFieldTypes(cfg config.Config, tableName string, fields []gen.Field, columnTypes map[string]string, meta map[string]postgresColumnMetadata) error {
+	applyColumnTypes(fields, columnTypes)
+	if err := applyLargeObjectColumns(tableName, fields, cfg.LargeObjectColumns[tableName], meta); err != nil {
+		return err
+	}
+	applyPostgresNullability(fields, meta, cfg.StrictNullability)
+	applyColumnTypeOverrides(fields, cfg.ColumnTypeOverridesByTable[tableName])
+	return nil
+}
+
+// applyColumnTypes sets the Go type of individual columns, such as the
+// generated CheckEnums types. Like applyLargeObjectColumns it runs before
+// applyPostgresNullability, which adds the pointer for nullable columns.
+func applyColumnTypes(fields []gen.Field, types map[string]string) {
+	for column, goType := range types {
+		if fld := findFieldByColumn(fields, column); fld != nil {
+			fld.Type = goType
+		}
+	}
+}
+
+// applyLargeObjectColumns maps the configured oid columns to
+// pgtypes.LargeObject. It runs before applyPostgresNullability, which adds the
+// pointer back for nullable columns. bytea columns are rejected: their value is
+// stored inline in the row, so the large object API cannot stream it.
+func applyLargeObjectColumns(tableName string, fields []gen.Field, columns []string, meta map[string]postgresColumnMetadata) error {
+	for _, column := range columns {
+		fld := findFieldByColumn(fields, column)
+		if fld == nil {
+			return fmt.Errorf("LargeObjectColumns[%q]: column %q not found", tableName, column)
+		}
+		switch dataType := meta[fld.ColumnName].DataType; dataType {
+		case "oid":
+			fld.Type = "pgtypes.LargeObject"
+		case "bytea":
+			return fmt.Errorf("LargeObjectColumns[%q]: column %q is bytea, which stores the value inline; store large objects in an oid column to stream them", tableName, column)
+		default:
+			return fmt.Errorf("LargeObjectColumns[%q]: column %q has type %q, want oid", tableName, column, dataType)
+		}
+	}
+	return nil
+}
+
+func isIntegerGoType(goType string) bool {
+	switch strings.TrimLeft(goType, "*") {
+	case "int", "int8", "int16", "int32", "int64", "uint", "uint8", "uint16", "uint32", "uint64":
+		return true
+	default:
+		return false
+	}
+}
@@ -0,0 +1,152 @@
+package generator
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/dan-sherwin/gormdb2struct/internal/config"
+	"gorm.io/gen"
+	"gorm.io/gen/field"
+)
+
+func TestApplyPostgresAutoIncrementMarksIntegerPrimaryKeys(t *testing.T) {
+	t.Parallel()
+
+	serialID := newTestField("ID", "id", "int64", field.GormTag{field.TagKeyGormPrimaryKey: {""}})
+	assignedID := newTestField("ID", "id", "int32", field.GormTag{field.TagKeyGormPrimaryKey: {""}})
+	textKey := newTestField("Code", "code", "string", field.GormTag{field.TagKeyGormPrimaryKey: {""}})
+	plain := newTestField("Count", "count", "int64", field.GormTag{})
+
+	applyPostgresAutoIncrement([]gen.Field{serialID}, map[string]postgresColumnMetadata{
+		"id": {ColumnName: "id", HasSequence: true},
+	})
+	applyPostgresAutoIncrement([]gen.Field{assignedID, textKey, plain}, map[string]postgresColumnMetadata{
+		"id":    {ColumnName: "id"},
+		"code":  {ColumnName: "code"},
+		"count": {ColumnName: "count"},
+	})
+
+	if got := serialID.GORMTag[field.TagKeyGormAutoIncrement]; len(got) != 1 || got[0] != "true" {
+		t.Fatalf("expected sequence-backed primary key to keep autoIncrement:true, got %v", got)
+	}
+	if got := assignedID.GORMTag[field.TagKeyGormAutoIncrement]; len(got) != 1 || got[0] != "false" {
+		t.Fatalf("expected application-assigned primary key to get autoIncrement:false, got %v", got)
+	}
+	if _, exists := textKey.GORMTag[field.TagKeyGormAutoIncrement]; exists {
+		t.Fatal("expected non-integer primary key to be left untouched")
+	}
+	if _, exists := plain.GORMTag[field.TagKeyGormAutoIncrement]; exists {
+		t.Fatal("expected non-primary-key column to be left untouched")
+	}
+}
+
+func TestApplyPostgresNullabilityKeepsGenPointersByDefault(t *testing.T) {
+	t.Parallel()
+
+	defaulted := newTestField("Status", "status", "*string", field.GormTag{field.TagKeyGormDefault: {"'open'"}})
+	nullable := newTestField("Note", "note", "string", nil)
+
+	applyPostgresNullability([]gen.Field{defaulted, nullable}, map[string]postgresColumnMetadata{
+		"status": {ColumnName: "status"},
+		"note":   {ColumnName: "note", IsNullable: true},
+	}, false)
+
+	if defaulted.Type != "*string" {
+		t.Fatalf("expected NOT NULL column with default to keep gen's pointer, got %q", defaulted.Type)
+	}
+	if nullable.Type != "*string" {
+		t.Fatalf("expected nullable column to be a pointer, got %q", nullable.Type)
+	}
+}
+
+func TestApplyPostgresNullabilityFollowsDeclaredNullability(t *testing.T) {
+	t.Parallel()
+
+	defaulted := newTestField("Status", "status", "*string", field.GormTag{field.TagKeyGormDefault: {"'open'"}})
+	nullable := newTestField("Note", "note", "string", nil)
+	deletedAt := newTestField("DeletedAt", "deleted_at", "gorm.DeletedAt", nil)
+	extra := newTestField("Owner", "", "*User", nil)
+
+	applyPostgresNullability([]gen.Field{defaulted, nullable, deletedAt, extra}, map[string]postgresColumnMetadata{
+		"status":     {ColumnName: "status"},
+		"note":       {ColumnName: "note", IsNullable: true},
+		"deleted_at": {ColumnName: "deleted_at", IsNullable: true},
+	}, true)
+
+	if defaulted.Type != "string" {
+		t.Fatalf("expected NOT NULL column with default to be a value type, got %q", defaulted.Type)
+	}
+	if nullable.Type != "*string" {
+		t.Fatalf("expected nullable column to be a pointer, got %q", nullable.Type)
+	}
+	if deletedAt.Type != "gorm.DeletedAt" {
+		t.Fatalf("expected gorm.DeletedAt to be left untouched, got %q", deletedAt.Type)
+	}
+	if extra.Type != "*User" {
+		t.Fatalf("expected non-column field to be left untouched, got %q", extra.Type)
+	}
+}
+
+func TestApplyPostgresFieldTypesKeepsPointerOverrideOnNotNullColumn(t *testing.T) {
+	t.Parallel()
+
+	total := newTestField("Total", "total", "*string", field.GormTag{field.TagKeyGormDefault: {"0"}})
+	tax := newTestField("Tax", "tax", "string", nil)
+	cfg := config.Config{
+		StrictNullability: true,
+		ColumnTypeOverridesByTable: map[string]map[string]string{
+			"orders": {"total": "*decimal.Decimal", "tax": "decimal.Decimal"},
+		},
+	}
+
+	if err := applyPostgresFieldTypes(cfg, "orders", []gen.Field{total, tax}, nil, map[string]postgresColumnMetadata{
+		"total": {ColumnName: "total"},
+		"tax":   {ColumnName: "tax", IsNullable: true},
+	}); err != nil {
+		t.Fatalf("apply field types: %v", err)
+	}
+
+	if total.Type != "*decimal.Decimal" {
+		t.Fatalf("expected the pointer override on a NOT NULL column to survive nullability, got %q", total.Type)
+	}
+	if tax.Type != "*decimal.Decimal" {
+		t.Fatalf("expected the nullable column to keep its pointer under the override, got %q", tax.Type)
+	}
+}
+
+func TestApplyLargeObjectColumnsMapsOIDColumns(t *testing.T) {
+	t.Parallel()
+
+	content := newTestField("ContentOid", "content_oid", "int64", nil)
+	body := newTestField("Body", "body", "[]byte", nil)
+	meta := map[string]postgresColumnMetadata{
+		"content_oid": {ColumnName: "content_oid", DataType: "oid"},
+		"body":        {ColumnName: "body", DataType: "bytea"},
+	}
+
+	if err := applyLargeObjectColumns("documents", []gen.Field{content, body}, []string{"content_oid"}, meta); err != nil {
+		t.Fatalf("apply large object columns: %v", err)
+	}
+	if content.Type != "pgtypes.LargeObject" {
+		t.Fatalf("expected oid column to become pgtypes.LargeObject, got %q", content.Type)
+	}
+	if body.Type != "[]byte" {
+		t.Fatalf("expected unlisted bytea column to stay []byte, got %q", body.Type)
+	}
+
+	err := applyLargeObjectColumns("documents", []gen.Field{content, body}, []string{"body"}, meta)
+	if err == nil || !strings.Contains(err.Error(), "is bytea") {
+		t.Fatalf("expected listed bytea column to be rejected, got %v", err)
+	}
+	err = applyLargeObjectColumns("documents", []gen.Field{content, body}, []string{"missing"}, meta)
+	if err == nil || !strings.Contains(err.Error(), "not found") {
+		t.Fatalf("expected unknown column to be rejected, got %v", err)
+	}
+}
+
+func newTestField(name, columnName, goType string, gormTag field.GormTag) gen.Field {
+	fld := gen.FieldNew(name, goType, field.Tag{})(nil)
+	fld.ColumnName = columnName
+	fld.GORMTag = gormTag
+	return fld
+}
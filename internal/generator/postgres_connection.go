@@ -6,7 +6,6 @@ import (
 	"log/slog"
 
 	"github.com/dan-sherwin/gormdb2struct/internal/config"
-	"gorm.io/driver/postgres"
 	"gorm.io/gorm"
 )
 
@@ -21,7 +20,7 @@ func openPostgresDB(ctx context.Context, logger *slog.Logger, cfg config.Config)
 		slog.String("db", cfg.DbName),
 	)
 
-	db, err := gorm.Open(postgres.Open(postgresDSN(cfg)), &gorm.Config{})
+	db, err := gorm.Open(postgresDialector(cfg), &gorm.Config{})
 	if err != nil {
 		return nil, fmt.Errorf("open PostgreSQL connection: %w", err)
 	}
@@ -0,0 +1,65 @@
+package generator
+
+import (
+	"path/filepath"
+	"sort"
+
+	"gorm.io/gen"
+)
+
+type postgresMatview struct {
+	StructName string
+	Name       string
+}
+
+// writePostgresMatviewRefresh emits Refresh<Model>(db, concurrently) for each
+// generated materialized view, running REFRESH MATERIALIZED VIEW with the
+// quoted view name baked in.
+func writePostgresMatviewRefresh(g *gen.Generator, matviews []postgresMatview) error {
+	if len(matviews) == 0 {
+		return nil
+	}
+
+	type refreshTarget struct {
+		StructName string
+		Name       string
+		Statement  string
+	}
+
+	data := struct {
+		PackageName string
+		Targets     []refreshTarget
+	}{
+		PackageName: modelsPackageName(g),
+	}
+	for _, matview := range matviews {
+		data.Targets = append(data.Targets, refreshTarget{
+			StructName: matview.StructName,
+			Name:       matview.Name,
+			Statement:  quoteIdent(matview.Name),
+		})
+	}
+	sort.Slice(data.Targets, func(i, j int) bool { return data.Targets[i].StructName < data.Targets[j].StructName })
+
+	rendered, err := renderTemplate("postgres_matview_refresh", postgresMatviewRefreshTemplate, data)
+	if err != nil {
+		return err
+	}
+	return writeFormattedGoFile(filepath.Join(g.ModelPkgPath, "zz_matview_refresh.gen.go"), rendered)
+}
+
+const postgresMatviewRefreshTemplate = `// Code generated by gormdb2struct; DO NOT EDIT.
+package {{.PackageName}}
+
+import "gorm.io/gorm"
+{{range .Targets}}
+// Refresh{{.StructName}} refreshes the {{printf "%q" .Name}} materialized view.
+// CONCURRENTLY keeps the view readable during the refresh but requires a
+// unique index on the view.
+func Refresh{{.StructName}}(db *gorm.DB, concurrently bool) error {
+	if concurrently {
+		return db.Exec({{printf "%q" (printf "REFRESH MATERIALIZED VIEW CONCURRENTLY %s" .Statement)}}).Error
+	}
+	return db.Exec({{printf "%q" (printf "REFRESH MATERIALIZED VIEW %s" .Statement)}}).Error
+}
+{{end}}`
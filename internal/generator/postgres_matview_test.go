@@ -0,0 +1,39 @@
+package generator
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestWritePostgresMatviewRefreshEmitsOneHelperPerMatview(t *testing.T) {
+	t.Parallel()
+
+	g := newTestGenerator(t)
+	matviews := []postgresMatview{
+		{StructName: "TicketRollup", Name: "ticket_rollup"},
+		{StructName: "DailyStat", Name: `daily"stats`},
+	}
+
+	if err := writePostgresMatviewRefresh(g, matviews); err != nil {
+		t.Fatalf("write matview refresh helpers: %v", err)
+	}
+
+	outFile := filepath.Join(g.ModelPkgPath, "zz_matview_refresh.gen.go")
+	assertFileContains(t, outFile, "func RefreshTicketRollup(db *gorm.DB, concurrently bool) error {")
+	assertFileContains(t, outFile, `return db.Exec("REFRESH MATERIALIZED VIEW CONCURRENTLY \"ticket_rollup\"").Error`)
+	assertFileContains(t, outFile, `return db.Exec("REFRESH MATERIALIZED VIEW \"ticket_rollup\"").Error`)
+	assertFileContains(t, outFile, `return db.Exec("REFRESH MATERIALIZED VIEW \"daily\"\"stats\"").Error`)
+}
+
+func TestWritePostgresMatviewRefreshSkipsWithoutMatviews(t *testing.T) {
+	t.Parallel()
+
+	g := newTestGenerator(t)
+	if err := writePostgresMatviewRefresh(g, nil); err != nil {
+		t.Fatalf("write matview refresh helpers: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(g.ModelPkgPath, "zz_matview_refresh.gen.go")); !os.IsNotExist(err) {
+		t.Fatalf("expected no refresh file without materialized views, err=%v", err)
+	}
+}
@@ -1,8 +1,12 @@
 package generator
 
 import (
+	"bytes"
+	"log/slog"
 	"strings"
 	"testing"
+
+	"github.com/dan-sherwin/gormdb2struct/internal/config"
 )
 
 func TestDefaultPostgresObjectsIncludesTablesViewsThenMaterializedViews(t *testing.T) {
@@ -82,3 +86,46 @@ func TestResolveConfiguredPostgresObjectsRejectsProcedures(t *testing.T) {
 		t.Fatalf("expected unsupported object kind error, got %v", err)
 	}
 }
+
+func TestExcludePostgresObjectsMatchesGlobsPerKind(t *testing.T) {
+	t.Parallel()
+
+	var logs bytes.Buffer
+	logger := slog.New(slog.NewTextHandler(&logs, nil))
+	objects := []postgresObject{
+		{Name: "tickets", Kind: postgresObjectTable},
+		{Name: "schema_migrations", Kind: postgresObjectTable},
+		{Name: "tickets_audit", Kind: postgresObjectTable},
+		{Name: "tmp_import", Kind: postgresObjectView},
+		{Name: "tmp_rollup", Kind: postgresObjectMaterializedView},
+		{Name: "mv_tmp_totals", Kind: postgresObjectMaterializedView},
+	}
+	listed := []string{"tickets", "tickets_audit"}
+	cfg := config.Config{
+		Objects:                  &listed,
+		ExcludeTables:            []string{"schema_migrations", "*_audit", "tmp_*"},
+		ExcludeMaterializedViews: []string{"mv_tmp_*"},
+	}
+
+	got := excludePostgresObjects(logger, cfg, objects)
+	want := []postgresObject{
+		{Name: "tickets", Kind: postgresObjectTable},
+		{Name: "tmp_rollup", Kind: postgresObjectMaterializedView},
+	}
+	if len(got) != len(want) {
+		t.Fatalf("expected %v, got %v", want, got)
+	}
+	for idx := range want {
+		if got[idx] != want[idx] {
+			t.Fatalf("expected %v, got %v", want, got)
+		}
+	}
+
+	output := logs.String()
+	if !strings.Contains(output, "Excluding object listed in Objects") || !strings.Contains(output, "object=tickets_audit") || !strings.Contains(output, "pattern=*_audit") {
+		t.Fatalf("expected the listed object's exclusion to be logged, got %q", output)
+	}
+	if strings.Contains(output, "schema_migrations") {
+		t.Fatalf("expected unlisted exclusions to stay below Info, got %q", output)
+	}
+}
@@ -0,0 +1,35 @@
+package generator
+
+import (
+	"database/sql"
+	"testing"
+
+	"github.com/dan-sherwin/gormdb2struct/internal/config"
+	"gorm.io/gorm/migrator"
+)
+
+func TestBuildPostgresDataTypeMapHonorsMoneyType(t *testing.T) {
+	t.Parallel()
+
+	money := migrator.ColumnType{ColumnTypeValue: sql.NullString{String: "money", Valid: true}}
+	cases := map[config.MoneyType]string{
+		"":                         "string",
+		config.MoneyTypeString:     "string",
+		config.MoneyTypeInt64Cents: "pgtypes.Money",
+		config.MoneyTypeDecimal:    "pgtypes.MoneyDecimal",
+	}
+	for moneyType, want := range cases {
+		dataTypeMap := buildPostgresDataTypeMap(config.Config{MoneyType: moneyType})
+		if got := dataTypeMap["money"](money); got != want {
+			t.Fatalf("MoneyType %q: got %q, want %q", moneyType, got, want)
+		}
+	}
+
+	explicit := buildPostgresDataTypeMap(config.Config{
+		MoneyType: config.MoneyTypeInt64Cents,
+		TypeMap:   map[string]string{"money": "decimal.Decimal"},
+	})
+	if got := explicit["money"](money); got != "decimal.Decimal" {
+		t.Fatalf("expected TypeMap to override MoneyType, got %q", got)
+	}
+}
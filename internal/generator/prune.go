@@ -0,0 +1,349 @@
+package generator
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"go/format"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/dan-sherwin/gormdb2struct/internal/config"
+	"gorm.io/gorm"
+)
+
+const genFileHeader = "// Code generated by gorm.io/gen. DO NOT EDIT."
+
+// Prune deletes the model and query files gorm.io/gen wrote for objects that
+// no longer exist in the database or are now excluded, and drops those models
+// from gen.go and zz_migrate.gen.go. Nothing else is regenerated. Objects
+// listed in Objects that are missing from the database are pruned like
+// dropped ones. It returns the removed paths.
+func (s *Service) Prune(ctx context.Context, cfg config.Config) ([]string, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	var names []string
+	switch cfg.DatabaseDialect {
+	case config.PostgreSQL:
+		db, err := openPostgresDB(ctx, s.logger, cfg)
+		if err != nil {
+			return nil, err
+		}
+		objects, err := postgresPruneObjects(s.logger, db, cfg)
+		if err != nil {
+			return nil, err
+		}
+		for _, object := range excludePostgresObjects(s.logger, cfg, objects) {
+			names = append(names, object.Name)
+		}
+	case config.SQLite:
+		db, err := openSQLiteDB(ctx, s.logger, cfg)
+		if err != nil {
+			return nil, err
+		}
+		if names, err = sqlitePruneObjectNames(s.logger, db, cfg); err != nil {
+			return nil, err
+		}
+	default:
+		return nil, fmt.Errorf("unsupported database dialect %q", cfg.DatabaseDialect)
+	}
+
+	keep, err := generatedFileNames(cfg, names)
+	if err != nil {
+		return nil, err
+	}
+	removed, err := pruneGeneratedFiles(cfg.OutPath, keep)
+	if err != nil {
+		return removed, err
+	}
+	return removed, pruneModelReferences(s.logger, cfg, names)
+}
+
+// postgresPruneObjects resolves the objects to keep like postgresObjects, but
+// drops configured objects that no longer exist instead of failing on them.
+func postgresPruneObjects(logger *slog.Logger, db *gorm.DB, cfg config.Config) ([]postgresObject, error) {
+	if cfg.Objects == nil {
+		return postgresObjects(db, cfg)
+	}
+	relations, err := loadPostgresRelations(db)
+	if err != nil {
+		return nil, err
+	}
+	routines, err := loadPostgresRoutines(db)
+	if err != nil {
+		return nil, err
+	}
+
+	relationNames := make(map[string]struct{}, len(relations))
+	for _, relation := range relations {
+		relationNames[relation.Name] = struct{}{}
+	}
+	present := make([]string, 0, len(*cfg.Objects))
+	for _, configured := range *cfg.Objects {
+		name, err := normalizePostgresObjectName(configured)
+		if err != nil {
+			return nil, err
+		}
+		_, isRelation := relationNames[name]
+		_, isRoutine := routines[name]
+		if !isRelation && !isRoutine {
+			logger.Info("Configured object no longer exists; pruning its files", slog.String("object", configured))
+			continue
+		}
+		present = append(present, configured)
+	}
+	return resolveConfiguredPostgresObjects(present, relations, routines)
+}
+
+// sqlitePruneObjectNames is sqliteObjectNames with configured objects that no
+// longer exist in sqlite_master left out.
+func sqlitePruneObjectNames(logger *slog.Logger, db *gorm.DB, cfg config.Config) ([]string, error) {
+	names, err := sqliteObjectNames(logger, db, cfg)
+	if err != nil || cfg.Objects == nil {
+		return names, err
+	}
+	existing, err := sqliteSchemaObjectNames(db)
+	if err != nil {
+		return nil, err
+	}
+	present := make([]string, 0, len(names))
+	for _, name := range names {
+		if _, exists := existing[name]; !exists {
+			logger.Info("Configured object no longer exists; pruning its files", slog.String("object", name))
+			continue
+		}
+		present = append(present, name)
+	}
+	return present, nil
+}
+
+// generatedFileNames returns the base names gen uses for the current objects,
+// both as rendered by ModelFileNamePattern and gen's lower-cased default, so a
+// file is only pruned when no current object could have produced it.
+func generatedFileNames(cfg config.Config, objectNames []string) (map[string]struct{}, error) {
+	keep := make(map[string]struct{}, len(objectNames)*2)
+	for _, name := range objectNames {
+		keep[name] = struct{}{}
+		keep[strings.ToLower(name)] = struct{}{}
+		fileName, err := cfg.ModelFileName(name, cfg.ModelStructName(name))
+		if err != nil {
+			return nil, err
+		}
+		if fileName != "" {
+			keep[fileName] = struct{}{}
+		}
+	}
+	return keep, nil
+}
+
+// pruneGeneratedFiles removes gen-written *.gen.go files in the query and
+// models directories whose base name is not in keep. Files without gen's
+// header, including gormdb2struct's own helper files, are never touched.
+func pruneGeneratedFiles(outPath string, keep map[string]struct{}) ([]string, error) {
+	var removed []string
+	for _, dir := range []string{outPath, filepath.Join(outPath, "models")} {
+		entries, err := os.ReadDir(dir)
+		if err != nil {
+			if os.IsNotExist(err) {
+				continue
+			}
+			return removed, fmt.Errorf("read output directory %s: %w", dir, err)
+		}
+		for _, entry := range entries {
+			baseName, ok := strings.CutSuffix(entry.Name(), ".gen.go")
+			if entry.IsDir() || !ok {
+				continue
+			}
+			if _, current := keep[baseName]; current {
+				continue
+			}
+			path := filepath.Join(dir, entry.Name())
+			generated, err := hasGenFileHeader(path)
+			if err != nil {
+				return removed, err
+			}
+			if !generated {
+				continue
+			}
+			if err := osRemove(path); err != nil {
+				return removed, fmt.Errorf("remove stale generated file %s: %w", path, err)
+			}
+			removed = append(removed, path)
+		}
+	}
+	sort.Strings(removed)
+	return removed, nil
+}
+
+// pruneModelReferences rewrites gen.go and models/zz_migrate.gen.go, the two
+// files that list every model, without the models whose objects are gone.
+// The dropped models are read from gen.go's Query struct. gormdb2struct's own
+// zz_ helper files are not rewritten; any that still mention a dropped model
+// are logged so the next full run can refresh them.
+func pruneModelReferences(logger *slog.Logger, cfg config.Config, objectNames []string) error {
+	genPath := filepath.Join(cfg.OutPath, "gen.go")
+	src, err := os.ReadFile(genPath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return fmt.Errorf("read generated file %s: %w", genPath, err)
+	}
+
+	kept := make(map[string]struct{}, len(objectNames))
+	for _, name := range objectNames {
+		kept[cfg.ModelStructName(name)] = struct{}{}
+	}
+	dropped := map[string]struct{}{}
+	for _, structName := range queryStructFieldNames(src) {
+		if _, ok := kept[structName]; !ok {
+			dropped[structName] = struct{}{}
+		}
+	}
+	if len(dropped) == 0 {
+		return nil
+	}
+
+	// gen writes one line per model in each of gen.go's var block, Query
+	// struct, and constructors, and every such line starts with the model's
+	// struct name.
+	if err := rewriteWithoutLines(genPath, src, func(line string) bool {
+		_, ok := dropped[leadingIdent(line)]
+		return ok
+	}); err != nil {
+		return err
+	}
+
+	migratePath := filepath.Join(cfg.OutPath, "models", "zz_migrate.gen.go")
+	migrate, err := os.ReadFile(migratePath)
+	switch {
+	case os.IsNotExist(err):
+	case err != nil:
+		return fmt.Errorf("read generated file %s: %w", migratePath, err)
+	default:
+		if err := rewriteWithoutLines(migratePath, migrate, func(line string) bool {
+			name, ok := strings.CutPrefix(line, "&")
+			if name, ok = strings.CutSuffix(name, "{},"); !ok {
+				return false
+			}
+			_, isDropped := dropped[name]
+			return isDropped
+		}); err != nil {
+			return err
+		}
+	}
+
+	return reportStaleHelperFiles(logger, cfg.OutPath, dropped)
+}
+
+// queryStructFieldNames returns the model fields of the Query struct in a
+// gen.go file.
+func queryStructFieldNames(src []byte) []string {
+	var names []string
+	inQuery := false
+	for _, line := range strings.Split(string(src), "\n") {
+		line = strings.TrimSpace(line)
+		switch {
+		case strings.HasPrefix(line, "type Query struct"):
+			inQuery = true
+		case inQuery && line == "}":
+			return names
+		case inQuery:
+			if name := leadingIdent(line); name != "" && name != "db" {
+				names = append(names, name)
+			}
+		}
+	}
+	return names
+}
+
+// leadingIdent returns the Go identifier a trimmed line starts with.
+func leadingIdent(line string) string {
+	end := strings.IndexFunc(line, func(r rune) bool {
+		return r != '_' && !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	if end < 0 {
+		return line
+	}
+	return line[:end]
+}
+
+// rewriteWithoutLines writes src back to path without the lines drop matches,
+// gofmt'ing the result so the remaining fields stay aligned.
+func rewriteWithoutLines(path string, src []byte, drop func(trimmed string) bool) error {
+	lines := strings.Split(string(src), "\n")
+	keptLines := lines[:0]
+	for _, line := range lines {
+		if !drop(strings.TrimSpace(line)) {
+			keptLines = append(keptLines, line)
+		}
+	}
+	formatted, err := format.Source([]byte(strings.Join(keptLines, "\n")))
+	if err != nil {
+		return fmt.Errorf("format pruned file %s: %w", path, err)
+	}
+	if err := os.WriteFile(path, formatted, 0o644); err != nil {
+		return fmt.Errorf("write pruned file %s: %w", path, err)
+	}
+	return nil
+}
+
+// reportStaleHelperFiles warns about gormdb2struct zz_ helper files that still
+// mention a dropped model or its query struct.
+func reportStaleHelperFiles(logger *slog.Logger, outPath string, dropped map[string]struct{}) error {
+	patterns := make([]*regexp.Regexp, 0, len(dropped))
+	for name := range dropped {
+		queryName := strings.ToLower(name[:1]) + name[1:]
+		patterns = append(patterns, regexp.MustCompile(`\b(`+regexp.QuoteMeta(name)+`|`+regexp.QuoteMeta(queryName)+`)\b`))
+	}
+	for _, dir := range []string{outPath, filepath.Join(outPath, "models")} {
+		paths, err := filepath.Glob(filepath.Join(dir, "zz_*.gen.go"))
+		if err != nil {
+			return fmt.Errorf("list helper files in %s: %w", dir, err)
+		}
+		for _, path := range paths {
+			if filepath.Base(path) == "zz_migrate.gen.go" {
+				continue
+			}
+			src, err := os.ReadFile(path)
+			if err != nil {
+				return fmt.Errorf("read generated file %s: %w", path, err)
+			}
+			for _, pattern := range patterns {
+				if pattern.Match(src) {
+					logger.Warn("Helper file still references a pruned model; run a full generation to refresh it", slog.String("path", path))
+					break
+				}
+			}
+		}
+	}
+	return nil
+}
+
+func hasGenFileHeader(path string) (bool, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return false, fmt.Errorf("open generated file %s: %w", path, err)
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+		return line == genFileHeader, nil
+	}
+	if err := scanner.Err(); err != nil {
+		return false, fmt.Errorf("read generated file %s: %w", path, err)
+	}
+	return false, nil
+}
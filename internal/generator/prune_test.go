@@ -0,0 +1,135 @@
+package generator
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/dan-sherwin/gormdb2struct/internal/config"
+	"github.com/glebarez/sqlite"
+	"gorm.io/gorm"
+)
+
+func TestPruneGeneratedFilesRemovesOnlyStaleGenFiles(t *testing.T) {
+	t.Parallel()
+
+	outPath := t.TempDir()
+	modelsPath := filepath.Join(outPath, "models")
+	if err := os.MkdirAll(modelsPath, 0o755); err != nil {
+		t.Fatalf("mkdir models: %v", err)
+	}
+	genFile := genFileHeader + "\n\npackage models\n"
+	files := map[string]string{
+		filepath.Join(outPath, "users.gen.go"):          genFile,
+		filepath.Join(outPath, "dropped.gen.go"):        genFile,
+		filepath.Join(outPath, "gen.go"):                genFile,
+		filepath.Join(modelsPath, "users.gen.go"):       genFile,
+		filepath.Join(modelsPath, "dropped.gen.go"):     genFile,
+		filepath.Join(modelsPath, "zz_preload.gen.go"):  "// Code generated by gormdb2struct; DO NOT EDIT.\npackage models\n",
+		filepath.Join(modelsPath, "handwritten.gen.go"): "package models\n",
+		filepath.Join(modelsPath, "users_extra.go"):     "package models\n",
+		filepath.Join(modelsPath, "Order.model.gen.go"): genFile,
+	}
+	for path, content := range files {
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatalf("write %s: %v", path, err)
+		}
+	}
+
+	cfg := config.Config{ModelFileNamePattern: "{{.Struct}}.model"}
+	keep, err := generatedFileNames(cfg, []string{"users", "orders"})
+	if err != nil {
+		t.Fatalf("generated file names: %v", err)
+	}
+	removed, err := pruneGeneratedFiles(outPath, keep)
+	if err != nil {
+		t.Fatalf("prune generated files: %v", err)
+	}
+
+	want := []string{
+		filepath.Join(outPath, "dropped.gen.go"),
+		filepath.Join(modelsPath, "dropped.gen.go"),
+	}
+	if !reflect.DeepEqual(removed, want) {
+		t.Fatalf("expected removed files %v, got %v", want, removed)
+	}
+	for path := range files {
+		_, err := os.Stat(path)
+		if wasRemoved := os.IsNotExist(err); wasRemoved != (path == want[0] || path == want[1]) {
+			t.Fatalf("unexpected state for %s: removed=%t", path, wasRemoved)
+		}
+	}
+}
+
+func TestPruneDropsMissingObjectsFromSharedFiles(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	dbPath := filepath.Join(dir, "prune.db")
+	db, err := gorm.Open(sqlite.Open(dbPath), &gorm.Config{})
+	if err != nil {
+		t.Fatalf("open SQLite fixture: %v", err)
+	}
+	for _, statement := range []string{
+		`CREATE TABLE widget (id INTEGER PRIMARY KEY, code TEXT NOT NULL)`,
+		`CREATE TABLE gadget (id INTEGER PRIMARY KEY, name TEXT NOT NULL)`,
+	} {
+		if err := db.Exec(statement).Error; err != nil {
+			t.Fatalf("create fixture: %v", err)
+		}
+	}
+
+	outPath := filepath.Join(dir, "generated")
+	objects := []string{"widget", "gadget"}
+	cfg := config.Config{
+		DatabaseDialect: config.SQLite,
+		SQLiteDBPath:    dbPath,
+		OutPath:         outPath,
+		Objects:         &objects,
+		DbInit:          config.GenerateDbInitConfig{Enabled: true, IncludeAutoMigrate: true},
+	}
+	service := New(nil)
+	if err := service.Generate(context.Background(), cfg); err != nil {
+		t.Fatalf("generate: %v", err)
+	}
+	assertFileContains(t, filepath.Join(outPath, "gen.go"), "Gadget")
+
+	if err := db.Exec(`DROP TABLE gadget`).Error; err != nil {
+		t.Fatalf("drop fixture table: %v", err)
+	}
+	sqlDB, err := db.DB()
+	if err != nil {
+		t.Fatalf("get sql.DB: %v", err)
+	}
+	_ = sqlDB.Close()
+	widgetPath := filepath.Join(outPath, "models", "widget.gen.go")
+	widget, err := os.ReadFile(widgetPath)
+	if err != nil {
+		t.Fatalf("read widget model: %v", err)
+	}
+	if err := os.WriteFile(widgetPath, append(widget, []byte("\n// kept by prune\n")...), 0o644); err != nil {
+		t.Fatalf("mark widget model: %v", err)
+	}
+
+	removed, err := service.Prune(context.Background(), cfg)
+	if err != nil {
+		t.Fatalf("prune: %v", err)
+	}
+	want := []string{
+		filepath.Join(outPath, "gadget.gen.go"),
+		filepath.Join(outPath, "models", "gadget.gen.go"),
+	}
+	if !reflect.DeepEqual(removed, want) {
+		t.Fatalf("expected removed files %v, got %v", want, removed)
+	}
+	assertFileContains(t, widgetPath, "// kept by prune")
+	for _, path := range []string{
+		filepath.Join(outPath, "gen.go"),
+		filepath.Join(outPath, "models", "zz_migrate.gen.go"),
+	} {
+		assertFileContains(t, path, "Widget")
+		assertFileNotContains(t, path, "Gadget")
+	}
+}
@@ -0,0 +1,118 @@
+package generator
+
+import (
+	"fmt"
+	"go/format"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/dan-sherwin/gormdb2struct/internal/config"
+	"gorm.io/gen"
+	"gorm.io/gorm"
+)
+
+// queryInterfaceScratchFile receives the second pass's own gen.go, which only
+// knows the interface tables and is deleted once the pass is done.
+const queryInterfaceScratchFile = "zz_query_interface_scratch.go"
+
+// queryMode is the gen mode of the main pass. With QueryInterfaceTables the
+// main pass writes concrete query types for everything and
+// applyQueryInterfaceTables upgrades the listed tables afterwards.
+func queryMode(cfg config.Config) gen.GenerateMode {
+	if cfg.ModelsOnly {
+		return gen.WithoutContext
+	}
+	mode := gen.WithoutContext | gen.WithDefaultQuery
+	if len(cfg.QueryInterfaceTables) == 0 {
+		mode |= gen.WithQueryInterface
+	}
+	return mode
+}
+
+// applyQueryInterfaceTables rewrites the query files of QueryInterfaceTables in
+// gen's WithQueryInterface mode. gen picks the mode per generator, so a second
+// generator renders just those tables over the concrete files of the main pass,
+// and the queryCtx fields in gen.go, the only part of it that depends on the
+// mode, are switched to the I<Model>Do interfaces.
+func applyQueryInterfaceTables(g *gen.Generator, cfg config.Config, db *gorm.DB, refs []modelRef) error {
+	if len(cfg.QueryInterfaceTables) == 0 || cfg.ModelsOnly {
+		return nil
+	}
+
+	structNames := make(map[string]string, len(refs))
+	for _, ref := range refs {
+		structNames[ref.TableName] = ref.StructName
+	}
+	iface := gen.NewGenerator(gen.Config{
+		OutPath:      cfg.OutPath,
+		OutFile:      queryInterfaceScratchFile,
+		ModelPkgPath: g.ModelPkgPath,
+		Mode:         gen.WithoutContext | gen.WithDefaultQuery | gen.WithQueryInterface,
+	})
+	iface.UseDB(db)
+
+	selected := make(map[string]struct{}, len(cfg.QueryInterfaceTables))
+	for _, table := range cfg.QueryInterfaceTables {
+		structName, ok := structNames[table]
+		if !ok {
+			return fmt.Errorf("QueryInterfaceTables: %q is not one of the generated objects", table)
+		}
+		info, ok := g.Data[structName]
+		if !ok || info == nil {
+			return fmt.Errorf("QueryInterfaceTables: no query struct was generated for %q", table)
+		}
+		if err := runGenStep(iface, db, "apply query interfaces", func() { iface.ApplyBasic(info.QueryStructMeta) }); err != nil {
+			return err
+		}
+		selected[structName] = struct{}{}
+	}
+	if err := executeGenerator(iface, db); err != nil {
+		return err
+	}
+	if err := os.Remove(filepath.Join(cfg.OutPath, queryInterfaceScratchFile)); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("remove query interface scratch file: %w", err)
+	}
+
+	return rewriteQueryCtxInterfaces(g.OutFile, selected)
+}
+
+var queryCtxFieldPattern = regexp.MustCompile(`^(\s*)(\w+)(\s+)\*\w+Do$`)
+
+// rewriteQueryCtxInterfaces switches the queryCtx fields of the given models
+// from the concrete *<model>Do type to I<Model>Do.
+func rewriteQueryCtxInterfaces(queryFile string, structNames map[string]struct{}) error {
+	content, err := os.ReadFile(queryFile)
+	if err != nil {
+		return fmt.Errorf("read query file %s: %w", queryFile, err)
+	}
+
+	lines := strings.Split(string(content), "\n")
+	inQueryCtx := false
+	for i, line := range lines {
+		switch {
+		case strings.HasPrefix(line, "type queryCtx struct"):
+			inQueryCtx = true
+		case inQueryCtx && strings.HasPrefix(line, "}"):
+			inQueryCtx = false
+		case inQueryCtx:
+			match := queryCtxFieldPattern.FindStringSubmatch(line)
+			if match == nil {
+				continue
+			}
+			if _, ok := structNames[match[2]]; ok {
+				lines[i] = match[1] + match[2] + match[3] + "I" + match[2] + "Do"
+			}
+		}
+	}
+
+	formatted, err := format.Source([]byte(strings.Join(lines, "\n")))
+	if err != nil {
+		return fmt.Errorf("format query file %s: %w", queryFile, err)
+	}
+	if err := os.WriteFile(queryFile, formatted, 0o644); err != nil {
+		return fmt.Errorf("write query file %s: %w", queryFile, err)
+	}
+	return nil
+}
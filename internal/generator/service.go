@@ -12,6 +12,7 @@ import (
 	"github.com/iancoleman/strcase"
 	"gorm.io/gen"
 	"gorm.io/gen/field"
+	"gorm.io/gorm"
 )
 
 type Service struct {
@@ -40,26 +41,85 @@ func (s *Service) Generate(ctx context.Context, cfg config.Config) error {
 	}
 }
 
-func newGenerator(outPath string) *gen.Generator {
+func newGenerator(cfg config.Config) *gen.Generator {
+	withTypeTag, withIndexTag := cfg.GenFieldTags()
 	return gen.NewGenerator(gen.Config{
-		OutPath:           outPath,
-		ModelPkgPath:      filepath.Join(outPath, "models"),
+		OutPath:           cfg.OutPath,
+		ModelPkgPath:      filepath.Join(cfg.OutPath, "models"),
 		WithUnitTest:      false,
 		FieldNullable:     true,
 		FieldCoverable:    true,
 		FieldSignable:     true,
-		FieldWithIndexTag: true,
-		FieldWithTypeTag:  true,
-		Mode:              gen.WithoutContext | gen.WithDefaultQuery | gen.WithQueryInterface,
+		FieldWithIndexTag: withIndexTag,
+		FieldWithTypeTag:  withTypeTag,
+		Mode:              queryMode(cfg),
 	})
 }
 
-func configureJSONTags(g *gen.Generator) {
+func configureJSONTags(g *gen.Generator, strategy config.JSONTagStrategy) {
 	g.WithJSONTagNameStrategy(func(col string) string {
-		return strcase.ToLowerCamel(col)
+		return jsonTagName(strategy, col)
 	})
 }
 
+// jsonTagName applies JSONTagStrategy to a column or ExtraFields struct
+// property name. The default is lower camel case.
+func jsonTagName(strategy config.JSONTagStrategy, name string) string {
+	switch strategy {
+	case config.JSONTagStrategySnake:
+		return strcase.ToSnake(name)
+	case config.JSONTagStrategyPascal:
+		return strcase.ToCamel(name)
+	case config.JSONTagStrategyNone:
+		return name
+	default:
+		return strcase.ToLowerCamel(name)
+	}
+}
+
+// cleanUpPath is the directory CleanUp clears: the whole output for a full
+// generation, only the models for ModelsOnly so existing query files survive.
+func cleanUpPath(cfg config.Config) string {
+	if cfg.ModelsOnly {
+		return filepath.Join(cfg.OutPath, "models")
+	}
+	return cfg.OutPath
+}
+
+// applyModels hands the models to gen so it also writes their query files.
+// With ModelsOnly the models stay registered by GenerateModelAs alone, and
+// Execute writes only the model files.
+func applyModels(g *gen.Generator, db *gorm.DB, cfg config.Config, models []any) error {
+	if cfg.ModelsOnly {
+		return nil
+	}
+	return runGenStep(g, db, "apply models", func() { g.ApplyBasic(models...) })
+}
+
+// prepareOutDirs makes sure OutPath and its models directory exist before
+// anything is written, creating them unless CreateOutDir is disabled.
+func prepareOutDirs(cfg config.Config) error {
+	for _, dir := range []string{cfg.OutPath, filepath.Join(cfg.OutPath, "models")} {
+		if cfg.CreatesOutDir() {
+			if err := os.MkdirAll(dir, 0o755); err != nil {
+				return fmt.Errorf("create output directory %s: %w", dir, err)
+			}
+			continue
+		}
+		info, err := os.Stat(dir)
+		if err != nil {
+			if os.IsNotExist(err) {
+				return fmt.Errorf("output directory %s does not exist and CreateOutDir is false", dir)
+			}
+			return fmt.Errorf("stat output directory %s: %w", dir, err)
+		}
+		if !info.IsDir() {
+			return fmt.Errorf("output path %s is not a directory", dir)
+		}
+	}
+	return nil
+}
+
 func cleanUp(outPath string) error {
 	if _, err := os.Stat(outPath); err != nil {
 		if os.IsNotExist(err) {
@@ -85,7 +145,7 @@ func cleanUp(outPath string) error {
 	})
 }
 
-func genRelationField(ef config.ExtraField, fld gen.Field) {
+func genRelationField(ef config.ExtraField, fld gen.Field, strategy config.JSONTagStrategy) {
 	baseType := ef.StructPropType
 	if idx := strings.LastIndex(ef.StructPropType, "."); idx != -1 {
 		baseType = ef.StructPropType[idx+1:]
@@ -100,7 +160,7 @@ func genRelationField(ef config.ExtraField, fld gen.Field) {
 	fld.Name = ef.StructPropName
 	fld.Type = baseType
 	fld.Tag = field.Tag{}
-	fld.Tag.Set("json", strcase.ToLowerCamel(ef.StructPropName))
+	fld.Tag.Set("json", jsonTagName(strategy, ef.StructPropName))
 	fld.GORMTag = field.GormTag{}
 	fld.GORMTag.Set("foreignKey", ef.FkStructPropName)
 	fld.GORMTag.Set("references", ef.RefStructPropName)
@@ -0,0 +1,303 @@
+package generator
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/dan-sherwin/gormdb2struct/internal/config"
+	"github.com/glebarez/sqlite"
+	"gorm.io/gen/field"
+	"gorm.io/gorm"
+)
+
+func TestNewGeneratorAppliesGenFieldTags(t *testing.T) {
+	t.Parallel()
+
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	if err != nil {
+		t.Fatalf("open in-memory SQLite: %v", err)
+	}
+	for _, stmt := range []string{
+		`CREATE TABLE widget (id INTEGER PRIMARY KEY, code TEXT NOT NULL)`,
+		`CREATE UNIQUE INDEX widget_code_key ON widget (code)`,
+	} {
+		if err := db.Exec(stmt).Error; err != nil {
+			t.Fatalf("create fixture: %v", err)
+		}
+	}
+
+	disabled := false
+	tests := []struct {
+		name          string
+		cfg           config.Config
+		wantTypeTag   bool
+		wantUniqueTag bool
+	}{
+		{name: "sqlite defaults", cfg: config.Config{DatabaseDialect: config.SQLite}, wantTypeTag: true, wantUniqueTag: true},
+		{name: "index tags disabled", cfg: config.Config{DatabaseDialect: config.SQLite, FieldWithIndexTag: &disabled}, wantTypeTag: true},
+		{name: "type tags disabled", cfg: config.Config{DatabaseDialect: config.SQLite, FieldWithTypeTag: &disabled}, wantUniqueTag: true},
+	}
+	for _, tt := range tests {
+		tt.cfg.OutPath = filepath.Join(t.TempDir(), "generated")
+		g := newGenerator(tt.cfg)
+		g.UseDB(db)
+		model := g.GenerateModelAs("widget", "Widget")
+
+		code := findFieldByColumn(model.Fields, "code")
+		if code == nil {
+			t.Fatalf("%s: code field not generated", tt.name)
+		}
+		if _, ok := code.GORMTag[field.TagKeyGormType]; ok != tt.wantTypeTag {
+			t.Fatalf("%s: expected type tag=%t, got %v", tt.name, tt.wantTypeTag, code.GORMTag)
+		}
+		if _, ok := code.GORMTag[field.TagKeyGormUniqueIndex]; ok != tt.wantUniqueTag {
+			t.Fatalf("%s: expected uniqueIndex tag=%t, got %v", tt.name, tt.wantUniqueTag, code.GORMTag)
+		}
+	}
+}
+
+func TestGenerateModelsOnlyWritesOnlyModelFiles(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	dbPath := filepath.Join(dir, "models_only.db")
+	db, err := gorm.Open(sqlite.Open(dbPath), &gorm.Config{})
+	if err != nil {
+		t.Fatalf("open SQLite fixture: %v", err)
+	}
+	if err := db.Exec(`CREATE TABLE widget (id INTEGER PRIMARY KEY, code TEXT NOT NULL)`).Error; err != nil {
+		t.Fatalf("create fixture: %v", err)
+	}
+	sqlDB, err := db.DB()
+	if err != nil {
+		t.Fatalf("get sql.DB: %v", err)
+	}
+	_ = sqlDB.Close()
+
+	outPath := filepath.Join(dir, "generated")
+	staleQuery := filepath.Join(outPath, "widget.gen.go")
+	if err := os.MkdirAll(outPath, 0o755); err != nil {
+		t.Fatalf("mkdir out path: %v", err)
+	}
+	if err := os.WriteFile(staleQuery, []byte(genFileHeader+"\npackage generated\n"), 0o644); err != nil {
+		t.Fatalf("write existing query file: %v", err)
+	}
+
+	cfg := config.Config{
+		DatabaseDialect: config.SQLite,
+		SQLiteDBPath:    dbPath,
+		OutPath:         outPath,
+		CleanUp:         true,
+		ModelsOnly:      true,
+		DbInit:          config.GenerateDbInitConfig{Enabled: true},
+	}
+	if err := New(nil).Generate(context.Background(), cfg); err != nil {
+		t.Fatalf("generate models only: %v", err)
+	}
+
+	assertFileContains(t, filepath.Join(outPath, "models", "widget.gen.go"), "type Widget struct {")
+	if _, err := os.Stat(staleQuery); err != nil {
+		t.Fatalf("expected CleanUp to leave query files alone with ModelsOnly: %v", err)
+	}
+	for _, name := range []string{"gen.go", "db_sqlite.go"} {
+		if _, err := os.Stat(filepath.Join(outPath, name)); !os.IsNotExist(err) {
+			t.Fatalf("expected ModelsOnly not to write %s, got %v", name, err)
+		}
+	}
+}
+
+func TestGenerateQueryInterfaceTablesLimitsInterfaces(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	dbPath := filepath.Join(dir, "query_interface.db")
+	db, err := gorm.Open(sqlite.Open(dbPath), &gorm.Config{})
+	if err != nil {
+		t.Fatalf("open SQLite fixture: %v", err)
+	}
+	for _, stmt := range []string{
+		`CREATE TABLE account (id INTEGER PRIMARY KEY, name TEXT)`,
+		`CREATE TABLE widget (id INTEGER PRIMARY KEY, code TEXT)`,
+	} {
+		if err := db.Exec(stmt).Error; err != nil {
+			t.Fatalf("create fixture: %v", err)
+		}
+	}
+	sqlDB, err := db.DB()
+	if err != nil {
+		t.Fatalf("get sql.DB: %v", err)
+	}
+	_ = sqlDB.Close()
+
+	outPath := filepath.Join(dir, "generated")
+	cfg := config.Config{
+		DatabaseDialect:      config.SQLite,
+		SQLiteDBPath:         dbPath,
+		OutPath:              outPath,
+		QueryInterfaceTables: []string{"account"},
+	}
+	if err := New(nil).Generate(context.Background(), cfg); err != nil {
+		t.Fatalf("generate: %v", err)
+	}
+
+	assertFileContains(t, filepath.Join(outPath, "account.gen.go"), "type IAccountDo interface {")
+	assertFileNotContains(t, filepath.Join(outPath, "widget.gen.go"), "IWidgetDo")
+	assertFileContains(t, filepath.Join(outPath, "gen.go"), "Account IAccountDo")
+	assertFileContains(t, filepath.Join(outPath, "gen.go"), "Widget  *widgetDo")
+	if _, err := os.Stat(filepath.Join(outPath, queryInterfaceScratchFile)); !os.IsNotExist(err) {
+		t.Fatalf("expected the scratch query file to be removed, got %v", err)
+	}
+
+	cfg.QueryInterfaceTables = []string{"missing"}
+	if err := New(nil).Generate(context.Background(), cfg); err == nil || !strings.Contains(err.Error(), `"missing" is not one of the generated objects`) {
+		t.Fatalf("expected an unknown QueryInterfaceTables entry to fail, got %v", err)
+	}
+}
+
+func TestGenerateAppliesJSONTagStrategy(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	dbPath := filepath.Join(dir, "json_tags.db")
+	db, err := gorm.Open(sqlite.Open(dbPath), &gorm.Config{})
+	if err != nil {
+		t.Fatalf("open SQLite fixture: %v", err)
+	}
+	for _, stmt := range []string{
+		`CREATE TABLE account (id INTEGER PRIMARY KEY, display_name TEXT)`,
+		`CREATE TABLE widget (id INTEGER PRIMARY KEY, account_id INTEGER, display_name TEXT, created_at DATETIME)`,
+	} {
+		if err := db.Exec(stmt).Error; err != nil {
+			t.Fatalf("create fixture: %v", err)
+		}
+	}
+	sqlDB, err := db.DB()
+	if err != nil {
+		t.Fatalf("get sql.DB: %v", err)
+	}
+	_ = sqlDB.Close()
+
+	outPath := filepath.Join(dir, "generated")
+	cfg := config.Config{
+		DatabaseDialect: config.SQLite,
+		SQLiteDBPath:    dbPath,
+		OutPath:         outPath,
+		JSONTagStrategy: config.JSONTagStrategySnake,
+		JSONTagOverridesByTable: map[string]map[string]string{
+			"widget": {"display_name": "label"},
+		},
+		ExtraFields: map[string][]config.ExtraField{
+			"widget": {{StructPropName: "OwnerAccount", StructPropType: "models.Account", FkStructPropName: "AccountID", RefStructPropName: "ID", Pointer: true}},
+		},
+	}
+	if err := New(nil).Generate(context.Background(), cfg); err != nil {
+		t.Fatalf("generate: %v", err)
+	}
+
+	widget := filepath.Join(outPath, "models", "widget.gen.go")
+	assertFileContains(t, widget, `json:"created_at"`)
+	assertFileContains(t, widget, `json:"account_id"`)
+	assertFileContains(t, widget, `json:"label"`)
+	assertFileContains(t, widget, `json:"owner_account"`)
+	assertFileContains(t, filepath.Join(outPath, "models", "account.gen.go"), `json:"display_name"`)
+}
+
+func TestGenerateTrimTablePrefixes(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	dbPath := filepath.Join(dir, "trim_prefixes.db")
+	db, err := gorm.Open(sqlite.Open(dbPath), &gorm.Config{})
+	if err != nil {
+		t.Fatalf("open SQLite fixture: %v", err)
+	}
+	for _, stmt := range []string{
+		`CREATE TABLE tbl_customer (id INTEGER PRIMARY KEY, name TEXT)`,
+		`CREATE TABLE customer (id INTEGER PRIMARY KEY, name TEXT)`,
+	} {
+		if err := db.Exec(stmt).Error; err != nil {
+			t.Fatalf("create fixture: %v", err)
+		}
+	}
+	sqlDB, err := db.DB()
+	if err != nil {
+		t.Fatalf("get sql.DB: %v", err)
+	}
+	_ = sqlDB.Close()
+
+	outPath := filepath.Join(dir, "generated")
+	existing := filepath.Join(outPath, "models", "existing.gen.go")
+	if err := os.MkdirAll(filepath.Dir(existing), 0o755); err != nil {
+		t.Fatalf("mkdir models: %v", err)
+	}
+	if err := os.WriteFile(existing, []byte("package models\n"), 0o644); err != nil {
+		t.Fatalf("write existing model: %v", err)
+	}
+
+	cfg := config.Config{
+		DatabaseDialect:   config.SQLite,
+		SQLiteDBPath:      dbPath,
+		OutPath:           outPath,
+		CleanUp:           true,
+		TrimTablePrefixes: []string{"tbl_"},
+	}
+	err = New(nil).Generate(context.Background(), cfg)
+	if err == nil || !strings.Contains(err.Error(), `both map to model struct Customer`) {
+		t.Fatalf("expected the collapsed struct names to be rejected, got %v", err)
+	}
+	if _, err := os.Stat(existing); err != nil {
+		t.Fatalf("expected a name collision to fail before CleanUp: %v", err)
+	}
+
+	cfg.ExcludeTables = []string{"customer"}
+	if err := New(nil).Generate(context.Background(), cfg); err != nil {
+		t.Fatalf("generate: %v", err)
+	}
+	model := filepath.Join(outPath, "models", "tbl_customer.gen.go")
+	assertFileContains(t, model, "type Customer struct {")
+	assertFileContains(t, model, `const TableNameCustomer = "tbl_customer"`)
+}
+
+func TestJSONTagName(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		strategy config.JSONTagStrategy
+		want     string
+	}{
+		{strategy: "", want: "createdAt"},
+		{strategy: config.JSONTagStrategyCamel, want: "createdAt"},
+		{strategy: config.JSONTagStrategySnake, want: "created_at"},
+		{strategy: config.JSONTagStrategyPascal, want: "CreatedAt"},
+		{strategy: config.JSONTagStrategyNone, want: "created_at"},
+	}
+	for _, tt := range tests {
+		if got := jsonTagName(tt.strategy, "created_at"); got != tt.want {
+			t.Fatalf("jsonTagName(%q, created_at) = %q, want %q", tt.strategy, got, tt.want)
+		}
+	}
+}
+
+func TestPrepareOutDirsHonorsCreateOutDir(t *testing.T) {
+	t.Parallel()
+
+	outPath := filepath.Join(t.TempDir(), "nested", "generated")
+	disabled := false
+	err := prepareOutDirs(config.Config{OutPath: outPath, CreateOutDir: &disabled})
+	if err == nil || !strings.Contains(err.Error(), "does not exist and CreateOutDir is false") {
+		t.Fatalf("expected missing OutPath to be reported, got %v", err)
+	}
+
+	if err := prepareOutDirs(config.Config{OutPath: outPath}); err != nil {
+		t.Fatalf("prepare output directories: %v", err)
+	}
+	if info, err := os.Stat(filepath.Join(outPath, "models")); err != nil || !info.IsDir() {
+		t.Fatalf("expected models directory to be created, got %v", err)
+	}
+	if err := prepareOutDirs(config.Config{OutPath: outPath, CreateOutDir: &disabled}); err != nil {
+		t.Fatalf("expected existing directories to be accepted, got %v", err)
+	}
+}
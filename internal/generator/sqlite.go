@@ -4,12 +4,12 @@ import (
 	"context"
 	"fmt"
 	"log/slog"
+	"os"
 	"strings"
 
 	"github.com/dan-sherwin/gormdb2struct/internal/config"
 	"github.com/dan-sherwin/gormdb2struct/sqlitetype"
 	"github.com/glebarez/sqlite"
-	"gorm.io/gen"
 	"gorm.io/gorm"
 )
 
@@ -18,29 +18,30 @@ func (s *Service) generateSQLite(ctx context.Context, cfg config.Config) error {
 		return err
 	}
 
-	s.logger.Info("Connecting to SQLite", slog.String("path", cfg.SQLiteDBPath))
-
-	db, err := gorm.Open(sqlite.Open(cfg.SQLiteDBPath), &gorm.Config{})
+	db, err := openSQLiteDB(ctx, s.logger, cfg)
 	if err != nil {
-		return fmt.Errorf("open SQLite database: %w", err)
+		return err
 	}
 
-	sqldb, err := db.DB()
+	objects, err := sqliteObjectNames(s.logger, db, cfg)
 	if err != nil {
-		return fmt.Errorf("get SQLite sql.DB handle: %w", err)
+		return err
 	}
-	if err := sqldb.PingContext(ctx); err != nil {
-		return fmt.Errorf("ping SQLite database: %w", err)
+	if err := checkStructNameCollisions(cfg, objects); err != nil {
+		return err
 	}
 
 	if cfg.CleanUp {
-		if err := cleanUp(cfg.OutPath); err != nil {
+		if err := cleanUp(cleanUpPath(cfg)); err != nil {
 			return err
 		}
 	}
+	if err := prepareOutDirs(cfg); err != nil {
+		return err
+	}
 
-	g := newGenerator(cfg.OutPath)
-	configureJSONTags(g)
+	g := newGenerator(cfg)
+	configureJSONTags(g, cfg.JSONTagStrategy)
 
 	dataTypeMap := sqlitetype.CloneTypeMap()
 	for columnType, goType := range cfg.TypeMap {
@@ -59,53 +60,142 @@ func (s *Service) generateSQLite(ctx context.Context, cfg config.Config) error {
 	g.WithImportPkgPath(mergeImportPaths(cfg.ImportPackagePaths, []string{"gorm.io/datatypes"})...)
 	g.UseDB(db)
 
-	objects, err := sqliteObjectNames(db, cfg)
+	viewNames, err := sqlitetype.LoadViewNames(db)
 	if err != nil {
 		return err
 	}
+	views := make(map[string]struct{}, len(viewNames))
+	for _, viewName := range viewNames {
+		views[viewName] = struct{}{}
+	}
 
 	models := make([]any, 0, len(objects))
+	refs := make([]modelRef, 0, len(objects))
+	fileNames := map[string]string{}
+	softDelete := newSoftDeleteOptions(cfg)
 	for _, objectName := range objects {
-		model := g.GenerateModel(objectName)
-		if extraFields, ok := cfg.ExtraFields[objectName]; ok {
-			for _, extraField := range extraFields {
-				fieldFactory := gen.FieldNew("", "", nil)
-				fld := fieldFactory(nil)
-				genRelationField(extraField, gen.Field(fld))
-				model.Fields = append(model.Fields, fld)
-			}
+		model := g.GenerateModelAs(objectName, cfg.ModelStructName(objectName))
+		_, isView := views[objectName]
+		if !isView {
+			applySoftDeleteFields(model.Fields, softDelete, nil)
 		}
-		if jsonOverrides, ok := cfg.JSONTagOverridesByTable[objectName]; ok {
-			for _, fld := range model.Fields {
-				if jsonTag, exists := jsonOverrides[fld.ColumnName]; exists {
-					fld.Tag.Set("json", jsonTag)
-					continue
-				}
-				if jsonTag, exists := jsonOverrides[fld.Name]; exists {
-					fld.Tag.Set("json", jsonTag)
-				}
-			}
+		applyColumnTypeOverrides(model.Fields, cfg.ColumnTypeOverridesByTable[objectName])
+		if isView {
+			applyReadOnlyFields(model.Fields)
+		}
+		appendExtraFields(&model.Fields, cfg.ExtraFields[objectName], cfg.JSONTagStrategy)
+		applyJSONTagOverrides(model.Fields, cfg.JSONTagOverridesByTable[objectName])
+		if err := applySensitiveColumns(objectName, model.Fields, cfg.SensitiveColumns[objectName]); err != nil {
+			return err
+		}
+		if err := applyModelFileName(cfg, &model.FileName, objectName, model.ModelStructName, fileNames); err != nil {
+			return err
 		}
 		models = append(models, model)
+		refs = append(refs, modelRef{TableName: objectName, StructName: model.ModelStructName, View: isView, Fields: &model.Fields})
 	}
 
-	g.ApplyBasic(models...)
-	g.Execute()
+	var foreignKeys []foreignKey
+	if needsForeignKeys(cfg) {
+		tableNames := make([]string, 0, len(objects))
+		for _, objectName := range objects {
+			if _, isView := views[objectName]; !isView {
+				tableNames = append(tableNames, objectName)
+			}
+		}
+		if foreignKeys, err = loadSQLiteForeignKeys(db, tableNames); err != nil {
+			return err
+		}
+	}
+	if cfg.DetectForeignKeys {
+		for _, skipped := range applyDetectedRelations(cfg, g, refs, foreignKeys) {
+			s.logger.Info("Skipping detected relation", "relation", skipped)
+		}
+	}
 
-	if cfg.DbInit.Enabled {
-		if err := WriteSQLiteDBInit(cfg, g); err != nil {
+	applyTransformModels(cfg.TransformModels, refs)
+	if err := checkMaxFields(s.logger, cfg, refs); err != nil {
+		return err
+	}
+	if err := validateTypeMapTypes(ctx, s.logger, cfg, refs); err != nil {
+		return err
+	}
+	if err := applyModels(g, db, cfg, models); err != nil {
+		return err
+	}
+	if err := executeGenerator(g, db); err != nil {
+		return err
+	}
+	if err := applyQueryInterfaceTables(g, cfg, db, refs); err != nil {
+		return err
+	}
+
+	if err := writeHelperFiles(s.logger, cfg, g, refs, foreignKeys); err != nil {
+		return err
+	}
+
+	if cfg.DbInit.Enabled && cfg.ModelsOnly {
+		s.logger.Info("Skipping DbInit because ModelsOnly is set")
+	} else if cfg.DbInit.Enabled {
+		if err := WriteSQLiteDBInit(cfg, g, viewStructNames(refs)); err != nil {
 			return err
 		}
 	}
 
-	return nil
+	return formatGeneratedFiles(cfg.OutPath)
+}
+
+func openSQLiteDB(ctx context.Context, logger *slog.Logger, cfg config.Config) (*gorm.DB, error) {
+	logger.Info("Connecting to SQLite", slog.String("path", cfg.SQLiteDBPath))
+	return connectSQLite(ctx, cfg.SQLiteDBPath)
+}
+
+// openSQLiteDBReadOnly opens an existing SQLite database in read-only mode,
+// so a mistyped path fails instead of leaving an empty database file behind.
+func openSQLiteDBReadOnly(ctx context.Context, logger *slog.Logger, cfg config.Config) (*gorm.DB, error) {
+	logger.Info("Connecting to SQLite read-only", slog.String("path", cfg.SQLiteDBPath))
+	if _, err := os.Stat(cfg.SQLiteDBPath); err != nil {
+		return nil, fmt.Errorf("open SQLite database: %w", err)
+	}
+	return connectSQLite(ctx, "file:"+cfg.SQLiteDBPath+"?mode=ro")
 }
 
-func sqliteObjectNames(db *gorm.DB, cfg config.Config) ([]string, error) {
+func connectSQLite(ctx context.Context, dsn string) (*gorm.DB, error) {
+	db, err := gorm.Open(sqlite.Open(dsn), &gorm.Config{})
+	if err != nil {
+		return nil, fmt.Errorf("open SQLite database: %w", err)
+	}
+
+	sqldb, err := db.DB()
+	if err != nil {
+		return nil, fmt.Errorf("get SQLite sql.DB handle: %w", err)
+	}
+	if err := sqldb.PingContext(ctx); err != nil {
+		return nil, fmt.Errorf("ping SQLite database: %w", err)
+	}
+
+	return db, nil
+}
+
+func sqliteObjectNames(logger *slog.Logger, db *gorm.DB, cfg config.Config) ([]string, error) {
+	names := []string(nil)
 	if cfg.Objects != nil {
-		return append([]string(nil), (*cfg.Objects)...), nil
+		names = append(names, (*cfg.Objects)...)
+	} else {
+		loaded, err := sqlitetype.LoadTableNames(db)
+		if err != nil {
+			return nil, err
+		}
+		names = loaded
+	}
+
+	kept := make([]string, 0, len(names))
+	for _, name := range names {
+		if !excludeObject(logger, cfg, "ExcludeTables", cfg.ExcludeTables, name) {
+			kept = append(kept, name)
+		}
 	}
-	return sqlitetype.LoadTableNames(db)
+	return kept, nil
 }
 
 func mergeImportPaths(existing []string, required []string) []string {
@@ -129,3 +219,20 @@ func mergeImportPaths(existing []string, required []string) []string {
 
 	return out
 }
+
+// sqliteSchemaObjectNames returns the user tables and views in sqlite_master.
+func sqliteSchemaObjectNames(db *gorm.DB) (map[string]struct{}, error) {
+	tableNames, err := sqlitetype.LoadTableNames(db)
+	if err != nil {
+		return nil, err
+	}
+	viewNames, err := sqlitetype.LoadViewNames(db)
+	if err != nil {
+		return nil, err
+	}
+	names := make(map[string]struct{}, len(tableNames)+len(viewNames))
+	for _, name := range append(tableNames, viewNames...) {
+		names[name] = struct{}{}
+	}
+	return names, nil
+}
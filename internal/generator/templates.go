@@ -8,6 +8,7 @@ import (
 	"path"
 	"path/filepath"
 	"sort"
+	"strconv"
 	"strings"
 	"text/template"
 
@@ -15,11 +16,13 @@ import (
 	"gorm.io/gen"
 )
 
-func WritePostgresDBInit(cfg config.Config, g *gen.Generator) error {
+// WritePostgresDBInit writes db.go into OutPath. viewStructNames lists the
+// view models, which AutoMigrate leaves out.
+func WritePostgresDBInit(cfg config.Config, g *gen.Generator, viewStructNames []string) error {
 	outPath := g.OutPath
 	fullPackageName := resolveOutPackagePath(cfg.OutPackagePath, outPath)
 	packageName := filepath.Base(outPath)
-	modelStructNames := sortedModelStructNames(g)
+	modelStructNames := migratableModelStructNames(g, viewStructNames)
 
 	data := struct {
 		PackageName                     string
@@ -29,27 +32,38 @@ func WritePostgresDBInit(cfg config.Config, g *gen.Generator) error {
 		DbName                          string
 		DbUser                          string
 		DbPassword                      string
+		ReadsEnv                        bool
 		DbSSLMode                       bool
+		DbSSLModeString                 string
 		IncludeAutoMigrate              bool
 		GenerateAppSettingsRegistration bool
 		UseSlogGormLogger               bool
+		CreateDatabaseIfMissing         bool
+		LogHandler                      string
+		LogLevel                        string
 		ModelStructNames                []string
+		Namespace                       string
 	}{
 		PackageName:                     packageName,
 		FullPackageName:                 fullPackageName,
-		DbHost:                          cfg.DbHost,
+		DbHost:                          dbInitSettingExpr(cfg, "DbHost", cfg.DbHost),
 		DbPort:                          cfg.DbPort,
-		DbName:                          cfg.DbName,
-		DbUser:                          cfg.DbUser,
-		DbPassword:                      cfg.DbPassword,
+		DbName:                          dbInitSettingExpr(cfg, "DbName", cfg.DbName),
+		DbUser:                          dbInitSettingExpr(cfg, "DbUser", cfg.DbUser),
+		DbPassword:                      dbInitSettingExpr(cfg, "DbPassword", cfg.DbPassword),
+		ReadsEnv:                        dbInitReadsEnv(cfg, "DbHost", "DbName", "DbUser", "DbPassword"),
 		DbSSLMode:                       cfg.DbSSLMode,
+		DbSSLModeString:                 strings.TrimSpace(cfg.DbSSLModeString),
 		IncludeAutoMigrate:              cfg.DbInit.IncludeAutoMigrate,
 		GenerateAppSettingsRegistration: cfg.DbInit.GenerateAppSettingsRegistration,
 		UseSlogGormLogger:               cfg.DbInit.UseSlogGormLogger,
+		CreateDatabaseIfMissing:         cfg.DbInit.CreateDatabaseIfMissing,
 		ModelStructNames:                modelStructNames,
+		Namespace:                       strings.TrimSpace(cfg.DbInit.Namespace),
 	}
+	data.LogHandler, data.LogLevel = dbInitLogging(cfg.DbInit)
 
-	tmpl, err := template.New("postgres_db_init").Parse(postgresDBInitTemplate)
+	tmpl, err := template.New("postgres_db_init").Funcs(dbInitTemplateFuncs(data.Namespace)).Parse(postgresDBInitTemplate)
 	if err != nil {
 		return fmt.Errorf("parse postgres DbInit template: %w", err)
 	}
@@ -67,35 +81,55 @@ func WritePostgresDBInit(cfg config.Config, g *gen.Generator) error {
 	if err := os.WriteFile(outFile, formatted, 0o644); err != nil {
 		return fmt.Errorf("write postgres DbInit file %s: %w", outFile, err)
 	}
+	if data.IncludeAutoMigrate {
+		if err := writeModelsAutoMigrate(g, modelStructNames); err != nil {
+			return err
+		}
+	}
 
-	return nil
+	if cfg.DbInit.GenerateDBStats {
+		if err := writeDBStats(outPath, packageName, data.Namespace); err != nil {
+			return err
+		}
+	}
+
+	return writeDSNBuilder(outPath, packageName, data.Namespace)
 }
 
-func WriteSQLiteDBInit(cfg config.Config, g *gen.Generator) error {
+// WriteSQLiteDBInit writes db_sqlite.go into OutPath. viewStructNames lists
+// the view models, which AutoMigrate leaves out.
+func WriteSQLiteDBInit(cfg config.Config, g *gen.Generator, viewStructNames []string) error {
 	outPath := g.OutPath
 	fullPackageName := resolveOutPackagePath(cfg.OutPackagePath, outPath)
 	packageName := filepath.Base(outPath)
-	modelStructNames := sortedModelStructNames(g)
+	modelStructNames := migratableModelStructNames(g, viewStructNames)
 
 	data := struct {
 		PackageName                     string
 		FullPackageName                 string
 		DbPath                          string
+		ReadsEnv                        bool
 		IncludeAutoMigrate              bool
 		GenerateAppSettingsRegistration bool
 		UseSlogGormLogger               bool
+		LogHandler                      string
+		LogLevel                        string
 		ModelStructNames                []string
+		Namespace                       string
 	}{
 		PackageName:                     packageName,
 		FullPackageName:                 fullPackageName,
-		DbPath:                          cfg.SQLiteDBPath,
+		DbPath:                          dbInitSettingExpr(cfg, "SQLiteDBPath", cfg.SQLiteDBPath),
+		ReadsEnv:                        dbInitReadsEnv(cfg, "SQLiteDBPath"),
 		IncludeAutoMigrate:              cfg.DbInit.IncludeAutoMigrate,
 		GenerateAppSettingsRegistration: cfg.DbInit.GenerateAppSettingsRegistration,
 		UseSlogGormLogger:               cfg.DbInit.UseSlogGormLogger,
 		ModelStructNames:                modelStructNames,
+		Namespace:                       strings.TrimSpace(cfg.DbInit.Namespace),
 	}
+	data.LogHandler, data.LogLevel = dbInitLogging(cfg.DbInit)
 
-	tmpl, err := template.New("sqlite_db_init").Parse(sqliteDBInitTemplate)
+	tmpl, err := template.New("sqlite_db_init").Funcs(dbInitTemplateFuncs(data.Namespace)).Parse(sqliteDBInitTemplate)
 	if err != nil {
 		return fmt.Errorf("parse sqlite DbInit template: %w", err)
 	}
@@ -113,8 +147,133 @@ func WriteSQLiteDBInit(cfg config.Config, g *gen.Generator) error {
 	if err := os.WriteFile(outFile, formatted, 0o644); err != nil {
 		return fmt.Errorf("write sqlite DbInit file %s: %w", outFile, err)
 	}
+	if data.IncludeAutoMigrate {
+		if err := writeModelsAutoMigrate(g, modelStructNames); err != nil {
+			return err
+		}
+	}
 
-	return nil
+	if cfg.DbInit.GenerateDBStats {
+		if err := writeDBStats(outPath, packageName, data.Namespace); err != nil {
+			return err
+		}
+	}
+
+	return writeDSNBuilder(outPath, packageName, data.Namespace)
+}
+
+// writeDSNBuilder writes dsn.go next to the DbInit file. Its BuildDSN is the
+// single place the generated code turns connection settings into a driver DSN,
+// for every dialect, so DbInit and applications that pick the dialect from
+// their own configuration build connection strings the same way.
+func writeDSNBuilder(outPath, packageName, namespace string) error {
+	data := struct {
+		PackageName string
+		Namespace   string
+		QuoteFunc   string
+	}{
+		PackageName: packageName,
+		Namespace:   namespace,
+		QuoteFunc:   namespacedUnexported(namespace, "QuoteDSNValue"),
+	}
+	rendered, err := renderTemplate("dsn_builder", dsnBuilderTemplate, data)
+	if err != nil {
+		return err
+	}
+	return writeFormattedGoFile(filepath.Join(outPath, "dsn.go"), rendered)
+}
+
+// writeDBStats writes db_stats.go next to the DbInit file, exposing the
+// connection pool statistics of the *sql.DB behind the generated DB.
+func writeDBStats(outPath, packageName, namespace string) error {
+	data := struct {
+		PackageName string
+		Namespace   string
+	}{
+		PackageName: packageName,
+		Namespace:   namespace,
+	}
+	rendered, err := renderTemplate("db_stats", dbStatsTemplate, data)
+	if err != nil {
+		return err
+	}
+	return writeFormattedGoFile(filepath.Join(outPath, "db_stats.go"), rendered)
+}
+
+// writeModelsAutoMigrate writes zz_migrate.gen.go into the models package.
+// Keeping the model list there means DbInit only calls models.AutoMigrate and
+// the models package never has to reach back into the root package, so
+// migration code cannot introduce an import cycle.
+func writeModelsAutoMigrate(g *gen.Generator, modelStructNames []string) error {
+	if len(modelStructNames) == 0 {
+		return nil
+	}
+
+	data := struct {
+		PackageName      string
+		ModelStructNames []string
+	}{
+		PackageName:      modelsPackageName(g),
+		ModelStructNames: modelStructNames,
+	}
+	rendered, err := renderTemplate("models_auto_migrate", modelsAutoMigrateTemplate, data)
+	if err != nil {
+		return err
+	}
+	return writeFormattedGoFile(filepath.Join(g.ModelPkgPath, "zz_migrate.gen.go"), rendered)
+}
+
+// dbInitLogging maps DbInit.LogFormat and LogLevel to the slog handler and
+// level identifiers rendered into DbInit. Both are empty when neither option is
+// set, leaving the application's slog configuration alone.
+func dbInitLogging(d config.GenerateDbInitConfig) (string, string) {
+	if d.LogFormat == "" && d.LogLevel == "" {
+		return "", ""
+	}
+	handler := "Text"
+	if d.LogFormat == "json" {
+		handler = "JSON"
+	}
+	level := "Info"
+	if d.LogLevel != "" {
+		level = strings.ToUpper(d.LogLevel[:1]) + d.LogLevel[1:]
+	}
+	return handler, level
+}
+
+// dbInitTemplateFuncs exposes namespace-aware naming helpers to the DbInit
+// templates so several generated packages can be combined without clashes.
+func dbInitTemplateFuncs(namespace string) template.FuncMap {
+	return template.FuncMap{
+		"settingKey": func(name string) string {
+			return namespacedUnexported(namespace, name)
+		},
+	}
+}
+
+// namespacedUnexported prefixes name with the DbInit namespace and lowercases
+// the first letter, so unexported globals such as setting keys and helper
+// functions stay distinct when several namespaces share a package.
+func namespacedUnexported(namespace, name string) string {
+	key := namespace + name
+	return strings.ToLower(key[:1]) + key[1:]
+}
+
+// migratableModelStructNames lists the models DbInit passes to AutoMigrate.
+// View models are skipped: AutoMigrate would create a table in their place.
+func migratableModelStructNames(g *gen.Generator, viewStructNames []string) []string {
+	views := make(map[string]struct{}, len(viewStructNames))
+	for _, name := range viewStructNames {
+		views[name] = struct{}{}
+	}
+	modelNames := make([]string, 0, len(g.Data))
+	for _, modelName := range sortedModelStructNames(g) {
+		if _, isView := views[modelName]; isView {
+			continue
+		}
+		modelNames = append(modelNames, modelName)
+	}
+	return modelNames
 }
 
 func sortedModelStructNames(g *gen.Generator) []string {
@@ -126,6 +285,40 @@ func sortedModelStructNames(g *gen.Generator) []string {
 	return modelNames
 }
 
+// dbInitSettingExpr renders a connection setting as the Go expression DbInit
+// assigns to its variable. A setting written with ${VAR} references becomes
+// os.Getenv calls joined with its literal parts, so values such as the
+// password are read at runtime and never embedded in the generated source.
+func dbInitSettingExpr(cfg config.Config, name, value string) string {
+	raw, ok := cfg.EnvReferences[name]
+	if !ok {
+		return strconv.Quote(value)
+	}
+
+	var parts []string
+	last := 0
+	for _, match := range config.EnvReferencePattern.FindAllStringSubmatchIndex(raw, -1) {
+		if match[0] > last {
+			parts = append(parts, strconv.Quote(raw[last:match[0]]))
+		}
+		parts = append(parts, fmt.Sprintf("os.Getenv(%q)", raw[match[2]:match[3]]))
+		last = match[1]
+	}
+	if last < len(raw) {
+		parts = append(parts, strconv.Quote(raw[last:]))
+	}
+	return strings.Join(parts, " + ")
+}
+
+func dbInitReadsEnv(cfg config.Config, names ...string) bool {
+	for _, name := range names {
+		if _, ok := cfg.EnvReferences[name]; ok {
+			return true
+		}
+	}
+	return false
+}
+
 func resolveOutPackagePath(explicit, outPath string) string {
 	if strings.TrimSpace(explicit) != "" {
 		return explicit
@@ -184,7 +377,12 @@ const postgresDBInitTemplate = `
 package {{.PackageName}}
 
 import (
-	utilities "github.com/dan-sherwin/go-utilities"
+	{{- if .LogHandler}}
+	"log/slog"
+	{{- end}}
+	{{- if or .LogHandler .ReadsEnv}}
+	"os"
+	{{- end}}
 	{{- if .GenerateAppSettingsRegistration}}
 	app_settings "github.com/dan-sherwin/go-app-settings"
 	{{- end}}
@@ -193,46 +391,119 @@ import (
 	{{- end}}
 	"gorm.io/driver/postgres"
 	"gorm.io/gorm"
-	{{- if .IncludeAutoMigrate}}
+	{{- if .CreateDatabaseIfMissing}}
+	"gorm.io/gorm/clause"
+	{{- end}}
+	{{- if and .IncludeAutoMigrate .ModelStructNames}}
 	"{{.FullPackageName}}/models"
 	{{- end}}
 )
 
 var (
-	DbHost     = {{printf "%q" .DbHost}}
-	DbPort     = {{.DbPort}}
-	DbName     = {{printf "%q" .DbName}}
-	DbUser     = {{printf "%q" .DbUser}}
-	DbPassword = {{printf "%q" .DbPassword}}
-	DbSSLMode  = {{.DbSSLMode}}
-	DB         *gorm.DB
+	{{.Namespace}}DbHost          = {{.DbHost}}
+	{{.Namespace}}DbPort          = {{.DbPort}}
+	{{.Namespace}}DbName          = {{.DbName}}
+	{{.Namespace}}DbUser          = {{.DbUser}}
+	{{.Namespace}}DbPassword      = {{.DbPassword}}
+	{{.Namespace}}DbSSLMode       = {{.DbSSLMode}}
+	// {{.Namespace}}DbSSLModeString, when set, is used as the sslmode verbatim, e.g. "verify-full".
+	{{.Namespace}}DbSSLModeString = {{printf "%q" .DbSSLModeString}}
+	{{.Namespace}}DB              *gorm.DB
 )
 
 {{- if .GenerateAppSettingsRegistration}}
 func init() {
-	app_settings.RegisterStringSetting("dbHost", "Hostname of the database", &DbHost)
-	app_settings.RegisterIntSetting("dbPort", "Port of the database", &DbPort)
-	app_settings.RegisterStringSetting("dbName", "Name of the database", &DbName)
-	app_settings.RegisterStringSetting("dbUser", "Username of the database", &DbUser)
-	app_settings.RegisterStringSetting("dbPassword", "Password of the database", &DbPassword)
-	app_settings.RegisterBoolSetting("dbSSLMode", "Whether to require SSL for the database connection", &DbSSLMode)
+	app_settings.RegisterStringSetting({{printf "%q" (settingKey "DbHost")}}, "Hostname of the database", &{{.Namespace}}DbHost)
+	app_settings.RegisterIntSetting({{printf "%q" (settingKey "DbPort")}}, "Port of the database", &{{.Namespace}}DbPort)
+	app_settings.RegisterStringSetting({{printf "%q" (settingKey "DbName")}}, "Name of the database", &{{.Namespace}}DbName)
+	app_settings.RegisterStringSetting({{printf "%q" (settingKey "DbUser")}}, "Username of the database", &{{.Namespace}}DbUser)
+	app_settings.RegisterStringSetting({{printf "%q" (settingKey "DbPassword")}}, "Password of the database", &{{.Namespace}}DbPassword)
+	app_settings.RegisterBoolSetting({{printf "%q" (settingKey "DbSSLMode")}}, "Whether to require SSL for the database connection", &{{.Namespace}}DbSSLMode)
+	app_settings.RegisterStringSetting({{printf "%q" (settingKey "DbSSLModeString")}}, "PostgreSQL sslmode for the database connection; overrides DbSSLMode when set", &{{.Namespace}}DbSSLModeString)
+}
+
+{{- end}}
+{{- if .CreateDatabaseIfMissing}}
+// {{.Namespace}}CreateDatabaseIfMissing connects to the "postgres" maintenance database
+// with the generated settings and creates {{.Namespace}}DbName when it does not exist yet.
+// It is a no-op when the database already exists.
+func {{.Namespace}}CreateDatabaseIfMissing() error {
+	dsn, err := {{.Namespace}}BuildDSN({{.Namespace}}DialectPostgreSQL, {{.Namespace}}DSNConfig{
+		Host:          {{.Namespace}}DbHost,
+		Port:          {{.Namespace}}DbPort,
+		Name:          "postgres",
+		User:          {{.Namespace}}DbUser,
+		Password:      {{.Namespace}}DbPassword,
+		SSLMode:       {{.Namespace}}DbSSLMode,
+		SSLModeString: {{.Namespace}}DbSSLModeString,
+	})
+	if err != nil {
+		return err
+	}
+	maintenanceDB, err := gorm.Open(postgres.Open(dsn), &gorm.Config{
+		{{- if .UseSlogGormLogger}}
+		Logger: slogGorm.New(),
+		{{- end}}
+	})
+	if err != nil {
+		return err
+	}
+	sqldb, err := maintenanceDB.DB()
+	if err != nil {
+		return err
+	}
+	defer sqldb.Close()
+
+	exists := func() (bool, error) {
+		var found bool
+		err := maintenanceDB.Raw("SELECT EXISTS (SELECT 1 FROM pg_database WHERE datname = ?)", {{.Namespace}}DbName).Scan(&found).Error
+		return found, err
+	}
+	found, err := exists()
+	if err != nil || found {
+		return err
+	}
+	if createErr := maintenanceDB.Exec("CREATE DATABASE ?", clause.Table{Name: {{.Namespace}}DbName}).Error; createErr != nil {
+		// Another process may have created it between the check and CREATE DATABASE.
+		if found, err := exists(); err == nil && found {
+			return nil
+		}
+		return createErr
+	}
+	return nil
 }
 
+{{end -}}
+// {{.Namespace}}DbInit opens the PostgreSQL database. If optionalDSN is provided, it overrides the generated connection string.
+{{- if .CreateDatabaseIfMissing}}
+// Without optionalDSN, the database is created first when it does not exist.
 {{- end}}
-// DbInit opens the PostgreSQL database. If optionalDSN is provided, it overrides the generated connection string.
-func DbInit(optionalDSN ...string) error {
+func {{.Namespace}}DbInit(optionalDSN ...string) error {
+	{{- if .LogHandler}}
+	slog.SetDefault(slog.New(slog.New{{.LogHandler}}Handler(os.Stderr, &slog.HandlerOptions{Level: slog.Level{{.LogLevel}}})))
+	{{- end}}
 	var dsn string
 	if len(optionalDSN) > 0 && optionalDSN[0] != "" {
 		dsn = optionalDSN[0]
 	} else {
-		dsn = utilities.DbDSN(utilities.DbDSNConfig{
-			Server:   DbHost,
-			Port:     DbPort,
-			Name:     DbName,
-			User:     DbUser,
-			Password: DbPassword,
-			SSLMode:  DbSSLMode,
+		{{- if .CreateDatabaseIfMissing}}
+		if err := {{.Namespace}}CreateDatabaseIfMissing(); err != nil {
+			return err
+		}
+		{{- end}}
+		var err error
+		dsn, err = {{.Namespace}}BuildDSN({{.Namespace}}DialectPostgreSQL, {{.Namespace}}DSNConfig{
+			Host:          {{.Namespace}}DbHost,
+			Port:          {{.Namespace}}DbPort,
+			Name:          {{.Namespace}}DbName,
+			User:          {{.Namespace}}DbUser,
+			Password:      {{.Namespace}}DbPassword,
+			SSLMode:       {{.Namespace}}DbSSLMode,
+			SSLModeString: {{.Namespace}}DbSSLModeString,
 		})
+		if err != nil {
+			return err
+		}
 	}
 
 	gormDB, err := gorm.Open(postgres.Open(dsn), &gorm.Config{
@@ -252,18 +523,14 @@ func DbInit(optionalDSN ...string) error {
 		return err
 	}
 
-	{{if .IncludeAutoMigrate}}
-	if err = gormDB.AutoMigrate(
-		{{- range .ModelStructNames}}
-		&models.{{.}}{},
-		{{- end}}
-	); err != nil {
+	{{if and .IncludeAutoMigrate .ModelStructNames}}
+	if err = models.AutoMigrate(gormDB); err != nil {
 		return err
 	}
 	{{end}}
 
 	SetDefault(gormDB)
-	DB = gormDB
+	{{.Namespace}}DB = gormDB
 	return nil
 }
 `
@@ -274,6 +541,12 @@ const sqliteDBInitTemplate = `
 package {{.PackageName}}
 
 import (
+	{{- if .LogHandler}}
+	"log/slog"
+	{{- end}}
+	{{- if or .LogHandler .ReadsEnv}}
+	"os"
+	{{- end}}
 	{{- if .GenerateAppSettingsRegistration}}
 	app_settings "github.com/dan-sherwin/go-app-settings"
 	{{- end}}
@@ -282,30 +555,37 @@ import (
 	slogGorm "github.com/orandin/slog-gorm"
 	{{- end}}
 	"gorm.io/gorm"
-	{{- if .IncludeAutoMigrate}}
+	{{- if and .IncludeAutoMigrate .ModelStructNames}}
 	"{{.FullPackageName}}/models"
 	{{- end}}
 )
 
 var (
-	DbPath = {{printf "%q" .DbPath}}
-	DB     *gorm.DB
+	{{.Namespace}}DbPath = {{.DbPath}}
+	{{.Namespace}}DB *gorm.DB
 )
 
 {{- if .GenerateAppSettingsRegistration}}
 func init() {
-	app_settings.RegisterStringSetting("dbPath", "Path of the database", &DbPath)
+	app_settings.RegisterStringSetting({{printf "%q" (settingKey "DbPath")}}, "Path of the database", &{{.Namespace}}DbPath)
 }
 
 {{- end}}
-// DbInit opens the SQLite database. If optionalFilePath is provided, it overrides the generated DbPath.
-func DbInit(optionalFilePath ...string) error {
-	filePath := DbPath
+// {{.Namespace}}DbInit opens the SQLite database. If optionalFilePath is provided, it overrides the generated DbPath.
+func {{.Namespace}}DbInit(optionalFilePath ...string) error {
+	{{- if .LogHandler}}
+	slog.SetDefault(slog.New(slog.New{{.LogHandler}}Handler(os.Stderr, &slog.HandlerOptions{Level: slog.Level{{.LogLevel}}})))
+	{{- end}}
+	filePath := {{.Namespace}}DbPath
 	if len(optionalFilePath) > 0 && optionalFilePath[0] != "" {
 		filePath = optionalFilePath[0]
 	}
+	dsn, err := {{.Namespace}}BuildDSN({{.Namespace}}DialectSQLite, {{.Namespace}}DSNConfig{Path: filePath})
+	if err != nil {
+		return err
+	}
 
-	gormDB, err := gorm.Open(sqlite.Open(filePath), &gorm.Config{
+	gormDB, err := gorm.Open(sqlite.Open(dsn), &gorm.Config{
 		{{- if .UseSlogGormLogger}}
 		Logger: slogGorm.New(),
 		{{- end}}
@@ -322,18 +602,143 @@ func DbInit(optionalFilePath ...string) error {
 		return err
 	}
 
-	{{if .IncludeAutoMigrate}}
-	if err = gormDB.AutoMigrate(
-		{{- range .ModelStructNames}}
-		&models.{{.}}{},
-		{{- end}}
-	); err != nil {
+	{{if and .IncludeAutoMigrate .ModelStructNames}}
+	if err = models.AutoMigrate(gormDB); err != nil {
 		return err
 	}
 	{{end}}
 
 	SetDefault(gormDB)
-	DB = gormDB
+	{{.Namespace}}DB = gormDB
 	return nil
 }
 `
+
+const modelsAutoMigrateTemplate = `// Code generated by gormdb2struct; DO NOT EDIT.
+package {{.PackageName}}
+
+import "gorm.io/gorm"
+
+// AutoMigrate migrates the generated table models. View models are left out
+// because migrating one would create a table in place of the view.
+func AutoMigrate(db *gorm.DB) error {
+	return db.AutoMigrate(
+		{{- range .ModelStructNames}}
+		&{{.}}{},
+		{{- end}}
+	)
+}
+`
+
+const dbStatsTemplate = `// Code generated by gormdb2struct; DO NOT EDIT.
+package {{.PackageName}}
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+)
+
+// {{.Namespace}}DBStats returns the connection pool statistics of {{.Namespace}}DB: open, in-use,
+// and idle connections plus wait counts and durations. It does not touch the
+// database, so it is cheap enough for a metrics collector.
+func {{.Namespace}}DBStats() (sql.DBStats, error) {
+	if {{.Namespace}}DB == nil {
+		return sql.DBStats{}, errors.New("{{.Namespace}}DBStats: {{.Namespace}}DbInit has not been called")
+	}
+	sqldb, err := {{.Namespace}}DB.DB()
+	if err != nil {
+		return sql.DBStats{}, err
+	}
+	return sqldb.Stats(), nil
+}
+
+// {{.Namespace}}DBHealth pings the database within ctx and returns the pool statistics
+// after the ping, for health checks and /debug endpoints.
+func {{.Namespace}}DBHealth(ctx context.Context) (sql.DBStats, error) {
+	if {{.Namespace}}DB == nil {
+		return sql.DBStats{}, errors.New("{{.Namespace}}DBHealth: {{.Namespace}}DbInit has not been called")
+	}
+	sqldb, err := {{.Namespace}}DB.DB()
+	if err != nil {
+		return sql.DBStats{}, err
+	}
+	if err := sqldb.PingContext(ctx); err != nil {
+		return sqldb.Stats(), err
+	}
+	return sqldb.Stats(), nil
+}
+`
+
+const dsnBuilderTemplate = `// Code generated by gormdb2struct; DO NOT EDIT.
+package {{.PackageName}}
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Dialects accepted by {{.Namespace}}BuildDSN. The values match the Dialect names of the generator config.
+const (
+	{{.Namespace}}DialectPostgreSQL = "postgresql"
+	{{.Namespace}}DialectSQLite     = "sqlite"
+)
+
+// {{.Namespace}}DSNConfig holds connection settings for {{.Namespace}}BuildDSN. PostgreSQL reads
+// Host, Port, Name, User, Password, SSLMode, and SSLModeString; SQLite reads Path.
+// SSLMode selects sslmode=require or sslmode=disable. SSLModeString, when set, is
+// passed through as the sslmode instead, e.g. "verify-full".
+type {{.Namespace}}DSNConfig struct {
+	Host          string
+	Port          int
+	Name          string
+	User          string
+	Password      string
+	SSLMode       bool
+	SSLModeString string
+	Path          string
+}
+
+// {{.Namespace}}BuildDSN returns the driver connection string for dialect.
+func {{.Namespace}}BuildDSN(dialect string, cfg {{.Namespace}}DSNConfig) (string, error) {
+	switch dialect {
+	case {{.Namespace}}DialectPostgreSQL:
+		parts := []string{"host=" + {{.QuoteFunc}}(cfg.Host), "dbname=" + {{.QuoteFunc}}(cfg.Name)}
+		if cfg.Port != 0 {
+			parts = append(parts, "port="+strconv.Itoa(cfg.Port))
+		}
+		if cfg.User != "" {
+			parts = append(parts, "user="+{{.QuoteFunc}}(cfg.User))
+		}
+		if cfg.Password != "" {
+			parts = append(parts, "password="+{{.QuoteFunc}}(cfg.Password))
+		}
+		switch {
+		case cfg.SSLModeString != "":
+			parts = append(parts, "sslmode="+{{.QuoteFunc}}(cfg.SSLModeString))
+		case cfg.SSLMode:
+			parts = append(parts, "sslmode=require")
+		default:
+			parts = append(parts, "sslmode=disable")
+		}
+		return strings.Join(parts, " "), nil
+	case {{.Namespace}}DialectSQLite:
+		if cfg.Path == "" {
+			return "", fmt.Errorf("sqlite DSN requires Path")
+		}
+		return cfg.Path, nil
+	default:
+		return "", fmt.Errorf("unsupported dialect %q", dialect)
+	}
+}
+
+// {{.QuoteFunc}} quotes a PostgreSQL keyword/value setting when it is empty or
+// contains spaces, quotes, or backslashes.
+func {{.QuoteFunc}}(value string) string {
+	if value != "" && !strings.ContainsAny(value, " '\\\t\n") {
+		return value
+	}
+	return "'" + strings.NewReplacer("\\", "\\\\", "'", "\\'").Replace(value) + "'"
+}
+`
@@ -0,0 +1,155 @@
+package generator
+
+import (
+	"context"
+	"fmt"
+	"go/types"
+	"log/slog"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/dan-sherwin/gormdb2struct/internal/config"
+	"golang.org/x/tools/go/packages"
+)
+
+type typeMapReference struct {
+	DatabaseType string
+	GoType       string
+	Qualifier    string
+	TypeName     string
+}
+
+// validateTypeMapTypes fails generation when a TypeMap entry used by a model
+// names a type that database/sql cannot scan into or write. Types count as
+// compatible when *T implements sql.Scanner and T implements driver.Valuer, or
+// when T has a basic underlying kind that database/sql converts on its own.
+// Packages that cannot be loaded are skipped with a warning.
+func validateTypeMapTypes(ctx context.Context, logger *slog.Logger, cfg config.Config, refs []modelRef) error {
+	references := typeMapReferences(cfg.TypeMap, refs)
+	if len(references) == 0 {
+		return nil
+	}
+
+	importPaths := filterInspectionImportPaths(cfg.ImportPackagePaths)
+	if len(importPaths) == 0 {
+		return nil
+	}
+	loaded, err := packages.Load(&packages.Config{
+		Context: ctx,
+		Mode:    packages.NeedName | packages.NeedTypes,
+		Env:     append(os.Environ(), "GOWORK=off"),
+	}, importPaths...)
+	if err != nil {
+		logger.Warn("Skipping TypeMap type validation", slog.String("error", err.Error()))
+		return nil
+	}
+	byName := make(map[string]*types.Package, len(loaded))
+	for _, pkg := range loaded {
+		if pkg == nil || pkg.Types == nil || len(pkg.Errors) > 0 {
+			continue
+		}
+		byName[pkg.Name] = pkg.Types
+	}
+
+	var offenders []string
+	for _, reference := range references {
+		pkg, ok := byName[reference.Qualifier]
+		if !ok {
+			logger.Warn("Skipping TypeMap type validation for unresolved package",
+				slog.String("type", reference.GoType),
+			)
+			continue
+		}
+		typeName, ok := pkg.Scope().Lookup(reference.TypeName).(*types.TypeName)
+		if !ok {
+			offenders = append(offenders, fmt.Sprintf("TypeMap[%q] = %q: type not found in %s", reference.DatabaseType, reference.GoType, pkg.Path()))
+			continue
+		}
+		if problem := sqlCompatibilityProblem(typeName.Type()); problem != "" {
+			offenders = append(offenders, fmt.Sprintf("TypeMap[%q] = %q: %s", reference.DatabaseType, reference.GoType, problem))
+		}
+	}
+	if len(offenders) > 0 {
+		return fmt.Errorf("TypeMap types are not usable as database columns:\n  %s", strings.Join(offenders, "\n  "))
+	}
+	return nil
+}
+
+// typeMapReferences returns the qualified named TypeMap targets that at least
+// one generated field uses, sorted by database type.
+func typeMapReferences(typeMap map[string]string, refs []modelRef) []typeMapReference {
+	used := map[string]struct{}{}
+	for _, ref := range refs {
+		for _, fld := range *ref.Fields {
+			if fld != nil {
+				used[strings.TrimLeft(fld.Type, "*")] = struct{}{}
+			}
+		}
+	}
+
+	var references []typeMapReference
+	for databaseType, goType := range typeMap {
+		cleaned := strings.TrimLeft(strings.TrimSpace(goType), "*")
+		if _, ok := used[cleaned]; !ok {
+			continue
+		}
+		qualifier, typeName, ok := strings.Cut(cleaned, ".")
+		if !ok || qualifier == "" || strings.ContainsAny(typeName, ".[]") {
+			continue
+		}
+		references = append(references, typeMapReference{
+			DatabaseType: databaseType,
+			GoType:       goType,
+			Qualifier:    qualifier,
+			TypeName:     typeName,
+		})
+	}
+	sort.Slice(references, func(i, j int) bool { return references[i].DatabaseType < references[j].DatabaseType })
+	return references
+}
+
+func sqlCompatibilityProblem(t types.Type) string {
+	if named, ok := t.(*types.Named); ok && named.Obj().Pkg() != nil && named.Obj().Pkg().Path() == "time" && named.Obj().Name() == "Time" {
+		return ""
+	}
+
+	hasScanner := hasMethod(types.NewPointer(t), "Scan", 1, 1)
+	hasValuer := hasMethod(types.NewPointer(t), "Value", 0, 2)
+	if hasScanner && hasValuer {
+		return ""
+	}
+	if isDriverConvertible(t.Underlying()) {
+		return ""
+	}
+
+	switch {
+	case !hasScanner && !hasValuer:
+		return "does not implement sql.Scanner or driver.Valuer"
+	case !hasScanner:
+		return "does not implement sql.Scanner"
+	default:
+		return "does not implement driver.Valuer"
+	}
+}
+
+func hasMethod(t types.Type, name string, params, results int) bool {
+	selection := types.NewMethodSet(t).Lookup(nil, name)
+	if selection == nil {
+		return false
+	}
+	signature, ok := selection.Type().(*types.Signature)
+	return ok && signature.Params().Len() == params && signature.Results().Len() == results
+}
+
+func isDriverConvertible(t types.Type) bool {
+	switch underlying := t.(type) {
+	case *types.Basic:
+		return underlying.Info()&(types.IsBoolean|types.IsNumeric|types.IsString) != 0 && underlying.Info()&types.IsComplex == 0
+	case *types.Slice:
+		basic, ok := underlying.Elem().Underlying().(*types.Basic)
+		return ok && basic.Kind() == types.Byte
+	default:
+		return false
+	}
+}
@@ -0,0 +1,47 @@
+package generator
+
+import (
+	"context"
+	"log/slog"
+	"strings"
+	"testing"
+
+	"github.com/dan-sherwin/gormdb2struct/internal/config"
+	"gorm.io/gen"
+)
+
+func TestValidateTypeMapTypesListsIncompatibleTypes(t *testing.T) {
+	t.Parallel()
+
+	fields := []gen.Field{
+		newTestField("ID", "id", "*sl_datatypes.SpacelinkIdentifier", nil),
+		newTestField("Types", "types", "sl_datatypes.TicketTypeArray", nil),
+		newTestField("Ghost", "ghost", "sl_datatypes.Ghost", nil),
+	}
+	cfg := config.Config{
+		ImportPackagePaths: []string{"github.com/dan-sherwin/gormdb2struct/internal/testfixtures/sl_datatypes"},
+		TypeMap: map[string]string{
+			"spacelink_identifier": "sl_datatypes.SpacelinkIdentifier",
+			"ticket_type[]":        "sl_datatypes.TicketTypeArray",
+			"ghost":                "sl_datatypes.Ghost",
+			"unused":               "sl_datatypes.TicketStatus",
+		},
+	}
+
+	err := validateTypeMapTypes(context.Background(), slog.Default(), cfg, []modelRef{{TableName: "tickets", Fields: &fields}})
+	if err == nil {
+		t.Fatal("expected incompatible TypeMap types to be rejected")
+	}
+	message := err.Error()
+	for _, want := range []string{
+		`TypeMap["ticket_type[]"] = "sl_datatypes.TicketTypeArray": does not implement sql.Scanner or driver.Valuer`,
+		`TypeMap["ghost"] = "sl_datatypes.Ghost": type not found`,
+	} {
+		if !strings.Contains(message, want) {
+			t.Fatalf("expected error to contain %q, got:\n%s", want, message)
+		}
+	}
+	if strings.Contains(message, "SpacelinkIdentifier") || strings.Contains(message, "TicketStatus") {
+		t.Fatalf("expected string-backed and unused types to pass, got:\n%s", message)
+	}
+}
@@ -0,0 +1,148 @@
+// Package pgtypes provides GORM-compatible custom PostgreSQL types.
+package pgtypes
+
+import (
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+
+	"gorm.io/gorm"
+)
+
+// Large object open modes from libpq (INV_WRITE | INV_READ).
+const largeObjectReadWrite = 0x20000 | 0x40000
+
+// LargeObject references a PostgreSQL large object by its OID, so loading a row
+// never pulls the object's content into memory. Stream the content through
+// Open, which uses the server-side large object functions and therefore needs
+// a transaction. The zero value means no object.
+type LargeObject uint32
+
+// CreateLargeObject creates an empty large object in tx and returns its OID.
+func CreateLargeObject(tx *gorm.DB) (LargeObject, error) {
+	var oid int64
+	if err := tx.Raw("SELECT lo_create(0)").Row().Scan(&oid); err != nil {
+		return 0, fmt.Errorf("create large object: %w", err)
+	}
+	return LargeObject(oid), nil
+}
+
+// Scan implements the sql.Scanner interface.
+func (o *LargeObject) Scan(src any) error {
+	switch v := src.(type) {
+	case nil:
+		*o = 0
+		return nil
+	case int64:
+		if v < 0 || v > int64(^uint32(0)) {
+			return fmt.Errorf("large object OID %d is out of range", v)
+		}
+		*o = LargeObject(v)
+		return nil
+	case uint32:
+		*o = LargeObject(v)
+		return nil
+	case []byte:
+		return o.Scan(string(v))
+	case string:
+		oid, err := strconv.ParseUint(v, 10, 32)
+		if err != nil {
+			return fmt.Errorf("cannot parse large object OID %q: %w", v, err)
+		}
+		*o = LargeObject(oid)
+		return nil
+	default:
+		return fmt.Errorf("cannot scan type %T into LargeObject", src)
+	}
+}
+
+// Value implements the driver.Valuer interface.
+func (o LargeObject) Value() (driver.Value, error) {
+	return int64(o), nil
+}
+
+// GormDataType implements the gorm.DataTypeInterface.
+func (LargeObject) GormDataType() string {
+	return "oid"
+}
+
+// OID returns the object identifier.
+func (o LargeObject) OID() uint32 {
+	return uint32(o)
+}
+
+// Open opens the object for reading and writing. tx must be a transaction:
+// the returned handle is only valid until it commits or rolls back.
+func (o LargeObject) Open(tx *gorm.DB) (*LargeObjectHandle, error) {
+	if o == 0 {
+		return nil, errors.New("open large object: no OID")
+	}
+	var fd int32
+	if err := tx.Raw("SELECT lo_open(?, ?)", int64(o), largeObjectReadWrite).Row().Scan(&fd); err != nil {
+		return nil, fmt.Errorf("open large object %d: %w", o, err)
+	}
+	return &LargeObjectHandle{tx: tx, fd: fd}, nil
+}
+
+// Unlink deletes the object. Rows that reference it keep the dangling OID.
+func (o LargeObject) Unlink(tx *gorm.DB) error {
+	var ok int32
+	if err := tx.Raw("SELECT lo_unlink(?)", int64(o)).Row().Scan(&ok); err != nil {
+		return fmt.Errorf("unlink large object %d: %w", o, err)
+	}
+	return nil
+}
+
+// LargeObjectHandle is an open large object. It implements io.Reader,
+// io.Writer, io.Seeker, and io.Closer, one server round trip per call.
+type LargeObjectHandle struct {
+	tx *gorm.DB
+	fd int32
+}
+
+// Read implements io.Reader.
+func (h *LargeObjectHandle) Read(p []byte) (int, error) {
+	if len(p) == 0 {
+		return 0, nil
+	}
+	var chunk []byte
+	if err := h.tx.Raw("SELECT loread(?, ?)", h.fd, len(p)).Row().Scan(&chunk); err != nil {
+		return 0, fmt.Errorf("read large object: %w", err)
+	}
+	if len(chunk) == 0 {
+		return 0, io.EOF
+	}
+	return copy(p, chunk), nil
+}
+
+// Write implements io.Writer.
+func (h *LargeObjectHandle) Write(p []byte) (int, error) {
+	var written int
+	if err := h.tx.Raw("SELECT lowrite(?, ?)", h.fd, p).Row().Scan(&written); err != nil {
+		return 0, fmt.Errorf("write large object: %w", err)
+	}
+	if written != len(p) {
+		return written, io.ErrShortWrite
+	}
+	return written, nil
+}
+
+// Seek implements io.Seeker. The io.Seek* constants match PostgreSQL's SEEK_* values.
+func (h *LargeObjectHandle) Seek(offset int64, whence int) (int64, error) {
+	var position int64
+	if err := h.tx.Raw("SELECT lo_lseek64(?, ?, ?)", h.fd, offset, whence).Row().Scan(&position); err != nil {
+		return 0, fmt.Errorf("seek large object: %w", err)
+	}
+	return position, nil
+}
+
+// Close implements io.Closer.
+func (h *LargeObjectHandle) Close() error {
+	var ok int32
+	if err := h.tx.Raw("SELECT lo_close(?)", h.fd).Row().Scan(&ok); err != nil {
+		return fmt.Errorf("close large object: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,42 @@
+package pgtypes
+
+import "testing"
+
+func TestLargeObject_Scan(t *testing.T) {
+	cases := []struct {
+		src  any
+		want LargeObject
+	}{
+		{nil, 0},
+		{int64(16403), 16403},
+		{uint32(16403), 16403},
+		{[]byte("16403"), 16403},
+		{"4294967295", 4294967295},
+	}
+	for _, c := range cases {
+		o := LargeObject(1)
+		if err := o.Scan(c.src); err != nil {
+			t.Fatalf("scan %v: %v", c.src, err)
+		}
+		if o != c.want {
+			t.Fatalf("scan %v: got %d, want %d", c.src, o, c.want)
+		}
+	}
+
+	var o LargeObject
+	for _, src := range []any{int64(-1), int64(1 << 32), "abc", 1.5} {
+		if err := o.Scan(src); err == nil {
+			t.Fatalf("expected scan of %v (%T) to fail", src, src)
+		}
+	}
+}
+
+func TestLargeObject_Value(t *testing.T) {
+	v, err := LargeObject(4294967295).Value()
+	if err != nil {
+		t.Fatalf("value: %v", err)
+	}
+	if v != int64(4294967295) {
+		t.Fatalf("value: got %v (%T), want int64 4294967295", v, v)
+	}
+}
@@ -0,0 +1,193 @@
+// Package pgtypes provides GORM-compatible custom PostgreSQL types.
+package pgtypes
+
+import (
+	"database/sql/driver"
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// Money holds a PostgreSQL money value as integer cents.
+//
+// PostgreSQL renders money using lc_monetary, so Scan accepts locale-formatted
+// text such as "$1,234.56", "-$5.00", "($5.00)", or "1.234,56 €". A trailing
+// group of exactly three digits is read as a thousands group, so locales with
+// three fractional digits are not supported.
+type Money int64
+
+// Scan implements the sql.Scanner interface.
+func (m *Money) Scan(src any) error {
+	text, err := moneySourceText(src, "Money")
+	if err != nil {
+		return err
+	}
+	if text == "" {
+		*m = 0
+		return nil
+	}
+
+	parsed, err := parseMoneyText(text)
+	if err != nil {
+		return err
+	}
+	if len(parsed.frac) > 2 {
+		return fmt.Errorf("money value %q has more than two fractional digits", text)
+	}
+	cents, err := strconv.ParseInt(parsed.whole+parsed.frac+strings.Repeat("0", 2-len(parsed.frac)), 10, 64)
+	if err != nil {
+		return fmt.Errorf("money value %q is out of range: %w", text, err)
+	}
+	if parsed.negative {
+		cents = -cents
+	}
+	*m = Money(cents)
+	return nil
+}
+
+// Value implements the driver.Valuer interface. The plain decimal text is
+// parsed by PostgreSQL's money input function.
+func (m Money) Value() (driver.Value, error) {
+	return m.String(), nil
+}
+
+// GormDataType implements the gorm.DataTypeInterface.
+func (Money) GormDataType() string {
+	return "money"
+}
+
+// Cents returns the amount in cents.
+func (m Money) Cents() int64 {
+	return int64(m)
+}
+
+// String returns the amount as a plain decimal, e.g. "-1234.56".
+func (m Money) String() string {
+	cents := int64(m)
+	sign := ""
+	if cents < 0 {
+		sign = "-"
+	}
+	abs := strconv.FormatUint(absInt64(cents), 10)
+	if len(abs) < 3 {
+		abs = strings.Repeat("0", 3-len(abs)) + abs
+	}
+	return sign + abs[:len(abs)-2] + "." + abs[len(abs)-2:]
+}
+
+// MoneyDecimal holds a PostgreSQL money value as a plain decimal string such as
+// "-1234.56", keeping every digit PostgreSQL returned without locale symbols.
+// Scan accepts the same locale-formatted text as Money.
+type MoneyDecimal string
+
+// Scan implements the sql.Scanner interface.
+func (m *MoneyDecimal) Scan(src any) error {
+	text, err := moneySourceText(src, "MoneyDecimal")
+	if err != nil {
+		return err
+	}
+	if text == "" {
+		*m = ""
+		return nil
+	}
+
+	parsed, err := parseMoneyText(text)
+	if err != nil {
+		return err
+	}
+	whole := strings.TrimLeft(parsed.whole, "0")
+	if whole == "" {
+		whole = "0"
+	}
+	out := whole
+	if parsed.frac != "" {
+		out += "." + parsed.frac
+	}
+	if parsed.negative && strings.Trim(parsed.whole+parsed.frac, "0") != "" {
+		out = "-" + out
+	}
+	*m = MoneyDecimal(out)
+	return nil
+}
+
+// Value implements the driver.Valuer interface. The zero value is stored as 0.
+func (m MoneyDecimal) Value() (driver.Value, error) {
+	if m == "" {
+		return "0", nil
+	}
+	return string(m), nil
+}
+
+// GormDataType implements the gorm.DataTypeInterface.
+func (MoneyDecimal) GormDataType() string {
+	return "money"
+}
+
+// String returns the decimal text.
+func (m MoneyDecimal) String() string {
+	return string(m)
+}
+
+type moneyText struct {
+	negative bool
+	whole    string
+	frac     string
+}
+
+func moneySourceText(src any, typeName string) (string, error) {
+	switch v := src.(type) {
+	case nil:
+		return "", nil
+	case string:
+		return strings.TrimSpace(v), nil
+	case []byte:
+		return strings.TrimSpace(string(v)), nil
+	default:
+		return "", fmt.Errorf("cannot scan type %T into %s", src, typeName)
+	}
+}
+
+// parseMoneyText strips currency symbols and grouping from a locale-formatted
+// money string. The last '.' or ',' is the decimal separator unless exactly
+// three digits follow it.
+func parseMoneyText(text string) (moneyText, error) {
+	var parsed moneyText
+	var digits strings.Builder
+	separatorAt := -1
+	for _, r := range text {
+		switch {
+		case r >= '0' && r <= '9':
+			digits.WriteRune(r)
+		case r == '.' || r == ',':
+			separatorAt = digits.Len()
+		case r == '-' || r == '(':
+			parsed.negative = true
+		case r == ')' || r == '\'' || unicode.IsSpace(r) || unicode.IsSymbol(r) || unicode.IsLetter(r):
+			// currency symbols, codes, and grouping characters
+		default:
+			return moneyText{}, fmt.Errorf("invalid money value %q", text)
+		}
+	}
+
+	all := digits.String()
+	if all == "" {
+		return moneyText{}, fmt.Errorf("invalid money value %q", text)
+	}
+	if separatorAt >= 0 && len(all)-separatorAt != 3 {
+		parsed.whole, parsed.frac = all[:separatorAt], all[separatorAt:]
+	} else {
+		parsed.whole = all
+	}
+	if parsed.whole == "" {
+		parsed.whole = "0"
+	}
+	return parsed, nil
+}
+
+func absInt64(v int64) uint64 {
+	if v < 0 {
+		return uint64(-(v + 1)) + 1
+	}
+	return uint64(v)
+}
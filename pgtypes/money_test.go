@@ -0,0 +1,62 @@
+// Package pgtypes provides GORM-compatible custom PostgreSQL types.
+package pgtypes
+
+import "testing"
+
+func TestMoney_ScanLocaleFormats(t *testing.T) {
+	cases := map[string]int64{
+		"$1,234.56":    123456,
+		"-$5.00":       -500,
+		"($0.07)":      -7,
+		"1.234,56 €":   123456,
+		"¥1,235":       123500,
+		"CHF 1'000.5":  100050,
+		"12.34":        1234,
+		"-92233720.99": -9223372099,
+	}
+	for text, want := range cases {
+		var m Money
+		if err := m.Scan([]byte(text)); err != nil {
+			t.Fatalf("scan %q: %v", text, err)
+		}
+		if m.Cents() != want {
+			t.Fatalf("scan %q: got %d cents, want %d", text, m.Cents(), want)
+		}
+	}
+
+	var m Money
+	if err := m.Scan("$1.2345"); err == nil {
+		t.Fatal("expected more than two fractional digits to be rejected")
+	}
+}
+
+func TestMoney_Value(t *testing.T) {
+	for cents, want := range map[Money]string{123456: "1234.56", -7: "-0.07", 0: "0.00"} {
+		v, err := cents.Value()
+		if err != nil {
+			t.Fatalf("value %d: %v", cents, err)
+		}
+		if v != want {
+			t.Fatalf("value %d: got %v, want %q", cents, v, want)
+		}
+	}
+}
+
+func TestMoneyDecimal_Scan(t *testing.T) {
+	cases := map[string]MoneyDecimal{
+		"$1,234.56":  "1234.56",
+		"(€0.50)":    "-0.50",
+		"1.234,5 €":  "1234.5",
+		"-$0.00":     "0.00",
+		"$00,012.30": "12.30",
+	}
+	for text, want := range cases {
+		var m MoneyDecimal
+		if err := m.Scan(text); err != nil {
+			t.Fatalf("scan %q: %v", text, err)
+		}
+		if m != want {
+			t.Fatalf("scan %q: got %q, want %q", text, m, want)
+		}
+	}
+}
@@ -131,6 +131,6 @@ var PgTypeMap = map[string]string{
 	"tstzmultirange": "string",
 	"datemultirange": "string",
 
-	// Money (locale-sensitive; treat as string or cents externally)
+	// Money (locale-sensitive text; set MoneyType to use Money or MoneyDecimal)
 	"money": "string",
 }
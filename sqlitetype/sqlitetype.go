@@ -2,7 +2,9 @@
 package sqlitetype
 
 import (
+	"database/sql"
 	"fmt"
+	"sort"
 	"strings"
 
 	"gorm.io/gorm"
@@ -116,6 +118,67 @@ func LoadTableNames(db *gorm.DB) ([]string, error) {
 	return tableNames, nil
 }
 
+// LoadViewNames returns user-defined SQLite view names in deterministic order.
+func LoadViewNames(db *gorm.DB) ([]string, error) {
+	viewNames := []string{}
+	err := db.Raw(`
+		SELECT name
+		FROM sqlite_master
+		WHERE type='view'
+		ORDER BY name
+	`).Scan(&viewNames).Error
+	if err != nil {
+		return nil, fmt.Errorf("load sqlite view names: %w", err)
+	}
+	return viewNames, nil
+}
+
+// ForeignKey is one foreign key constraint with its columns in key order.
+type ForeignKey struct {
+	Table      string
+	Columns    []string
+	RefTable   string
+	RefColumns []string
+}
+
+// LoadForeignKeys returns the foreign keys declared on tableName via
+// PRAGMA foreign_key_list. RefColumns is empty when the constraint references
+// the parent's primary key implicitly.
+func LoadForeignKeys(db *gorm.DB, tableName string) ([]ForeignKey, error) {
+	type foreignKeyRow struct {
+		ID    int            `gorm:"column:id"`
+		Seq   int            `gorm:"column:seq"`
+		Table string         `gorm:"column:table"`
+		From  string         `gorm:"column:from"`
+		To    sql.NullString `gorm:"column:to"`
+	}
+
+	var rows []foreignKeyRow
+	quoted := `"` + strings.ReplaceAll(tableName, `"`, `""`) + `"`
+	if err := db.Raw("PRAGMA foreign_key_list(" + quoted + ")").Scan(&rows).Error; err != nil {
+		return nil, fmt.Errorf("load sqlite foreign keys for %s: %w", tableName, err)
+	}
+	sort.SliceStable(rows, func(i, j int) bool {
+		if rows[i].ID != rows[j].ID {
+			return rows[i].ID < rows[j].ID
+		}
+		return rows[i].Seq < rows[j].Seq
+	})
+
+	var keys []ForeignKey
+	for i, row := range rows {
+		if i == 0 || row.ID != rows[i-1].ID {
+			keys = append(keys, ForeignKey{Table: tableName, RefTable: row.Table})
+		}
+		key := &keys[len(keys)-1]
+		key.Columns = append(key.Columns, row.From)
+		if row.To.Valid && row.To.String != "" {
+			key.RefColumns = append(key.RefColumns, row.To.String)
+		}
+	}
+	return keys, nil
+}
+
 // CloneTypeMap returns a shallow copy of the default SQLite type map so callers
 // can override mappings without mutating package-level defaults.
 func CloneTypeMap() map[string]func(gorm.ColumnType) string {
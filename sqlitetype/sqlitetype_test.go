@@ -6,6 +6,7 @@ import (
 	"strings"
 	"testing"
 
+	"github.com/glebarez/sqlite"
 	"gorm.io/gorm"
 )
 
@@ -104,5 +105,41 @@ func TestTypeMap_IntegerAndTextAndDate(t *testing.T) {
 	}
 }
 
+func TestLoadForeignKeysGroupsColumnsInKeyOrder(t *testing.T) {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	if err != nil {
+		t.Fatalf("open in-memory SQLite: %v", err)
+	}
+	for _, stmt := range []string{
+		`CREATE TABLE tenant_user (tenant_id INTEGER, user_id INTEGER, PRIMARY KEY (tenant_id, user_id))`,
+		`CREATE TABLE team (id INTEGER PRIMARY KEY)`,
+		`CREATE TABLE membership (
+			team_id INTEGER REFERENCES team,
+			tenant_id INTEGER,
+			user_id INTEGER,
+			FOREIGN KEY (tenant_id, user_id) REFERENCES tenant_user (tenant_id, user_id)
+		)`,
+	} {
+		if err := db.Exec(stmt).Error; err != nil {
+			t.Fatalf("create fixture: %v", err)
+		}
+	}
+
+	keys, err := LoadForeignKeys(db, "membership")
+	if err != nil {
+		t.Fatalf("load foreign keys: %v", err)
+	}
+	byRefTable := map[string]ForeignKey{}
+	for _, key := range keys {
+		byRefTable[key.RefTable] = key
+	}
+	if got := byRefTable["tenant_user"]; !reflect.DeepEqual(got.Columns, []string{"tenant_id", "user_id"}) || !reflect.DeepEqual(got.RefColumns, []string{"tenant_id", "user_id"}) {
+		t.Fatalf("unexpected composite foreign key: %+v", got)
+	}
+	if got := byRefTable["team"]; !reflect.DeepEqual(got.Columns, []string{"team_id"}) || len(got.RefColumns) != 0 {
+		t.Fatalf("expected implicit primary key reference without RefColumns, got %+v", got)
+	}
+}
+
 // Ensure the package compiles references for gorm.DB in signatures (unused import fix)
 var _ = gorm.DB{}